@@ -0,0 +1,268 @@
+package dify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	appInfoEndpoint       = "/v1/info"       // Endpoint for the basic information of the application.
+	appParametersEndpoint = "/v1/parameters" // Endpoint for the parameters of the application.
+	appMetaEndpoint       = "/v1/meta"       // Endpoint for the meta information of the application.
+	appSiteEndpoint       = "/v1/site"       // Endpoint for the WebApp settings of the application.
+)
+
+// AppInfo - Basic information of the application.
+type AppInfo struct {
+	Name        string   `json:"name"`        // Application name.
+	Description string   `json:"description"` // Application description.
+	Tags        []string `json:"tags"`        // Application tags.
+	Mode        string   `json:"mode"`        // Application mode, such as `chat`, `advanced-chat`, `completion` or `workflow`.
+	AuthorName  string   `json:"author_name"` // Application author name.
+}
+
+// EnabledSetting - Feature setting that can only be enabled or disabled.
+type EnabledSetting struct {
+	Enabled bool `json:"enabled"` // Whether the feature is enabled.
+}
+
+// TextToSpeechSetting - Text to speech setting.
+type TextToSpeechSetting struct {
+	Enabled  bool   `json:"enabled"`  // Whether text to speech is enabled.
+	Voice    string `json:"voice"`    // Voice of the speech.
+	Language string `json:"language"` // Language of the speech.
+	AutoPlay string `json:"autoPlay"` // Auto-play of the speech, `enabled` or `disabled`.
+}
+
+// ImageUploadSetting - Image upload setting.
+type ImageUploadSetting struct {
+	Enabled         bool             `json:"enabled"`          // Whether image upload is enabled.
+	NumberLimits    int              `json:"number_limits"`    // Maximum number of images.
+	Detail          string           `json:"detail"`           // Image detail level, such as `high` or `low`.
+	TransferMethods []TransferMethod `json:"transfer_methods"` // Allowed transfer methods.
+}
+
+// FileUploadSetting - File upload setting.
+type FileUploadSetting struct {
+	Enabled                  bool               `json:"enabled"`                     // Whether file upload is enabled.
+	AllowedFileTypes         []FileType         `json:"allowed_file_types"`          // Allowed file types.
+	AllowedFileExtensions    []string           `json:"allowed_file_extensions"`     // Allowed file extensions, for `custom` files.
+	AllowedFileUploadMethods []TransferMethod   `json:"allowed_file_upload_methods"` // Allowed transfer methods.
+	NumberLimits             int                `json:"number_limits"`               // Maximum number of files.
+	Image                    ImageUploadSetting `json:"image"`                       // Image upload setting.
+}
+
+// SystemParameters - System limits of the application.
+type SystemParameters struct {
+	FileSizeLimit           int `json:"file_size_limit"`            // Document upload size limit (MB).
+	ImageFileSizeLimit      int `json:"image_file_size_limit"`      // Image upload size limit (MB).
+	AudioFileSizeLimit      int `json:"audio_file_size_limit"`      // Audio upload size limit (MB).
+	VideoFileSizeLimit      int `json:"video_file_size_limit"`      // Video upload size limit (MB).
+	WorkflowFileUploadLimit int `json:"workflow_file_upload_limit"` // Maximum number of files of a workflow file list variable.
+}
+
+// FormControl - Fields shared by all the controls of the user input form.
+type FormControl struct {
+	Label    string      `json:"label"`             // Variable display label name.
+	Variable string      `json:"variable"`          // Variable ID.
+	Required bool        `json:"required"`          // Whether it is required.
+	Default  interface{} `json:"default,omitempty"` // Default value.
+}
+
+// TextInputControl - Text input or paragraph control.
+type TextInputControl struct {
+	FormControl
+	MaxLength int `json:"max_length,omitempty"` // Maximum number of characters, 0 for no limit.
+}
+
+// SelectControl - Drop-down control.
+type SelectControl struct {
+	FormControl
+	Options []string `json:"options"` // Option values.
+}
+
+// NumberControl - Number input control.
+type NumberControl struct {
+	FormControl
+}
+
+// FileControl - Single file or file list control.
+type FileControl struct {
+	FormControl
+	AllowedFileTypes         []FileType       `json:"allowed_file_types,omitempty"`          // Allowed file types.
+	AllowedFileExtensions    []string         `json:"allowed_file_extensions,omitempty"`     // Allowed file extensions, for `custom` files.
+	AllowedFileUploadMethods []TransferMethod `json:"allowed_file_upload_methods,omitempty"` // Allowed transfer methods.
+	MaxLength                int              `json:"max_length,omitempty"`                  // Maximum number of files, for file lists.
+}
+
+// UserInputFormItem - Control of the user input form, exactly one of the fields is set.
+type UserInputFormItem struct {
+	TextInput *TextInputControl `json:"text-input,omitempty"` // Text input control.
+	Paragraph *TextInputControl `json:"paragraph,omitempty"`  // Paragraph text input control.
+	Select    *SelectControl    `json:"select,omitempty"`     // Drop-down control.
+	Number    *NumberControl    `json:"number,omitempty"`     // Number input control.
+	File      *FileControl      `json:"file,omitempty"`       // Single file control.
+	FileList  *FileControl      `json:"file-list,omitempty"`  // File list control.
+}
+
+// Type - Returns the control type, such as `text-input` or `select`, empty for unknown controls.
+func (i UserInputFormItem) Type() string {
+	switch {
+	case i.TextInput != nil:
+		return "text-input"
+	case i.Paragraph != nil:
+		return "paragraph"
+	case i.Select != nil:
+		return "select"
+	case i.Number != nil:
+		return "number"
+	case i.File != nil:
+		return "file"
+	case i.FileList != nil:
+		return "file-list"
+	}
+	return ""
+}
+
+// Control - Returns the fields shared by all controls, nil for unknown controls.
+func (i UserInputFormItem) Control() *FormControl {
+	switch {
+	case i.TextInput != nil:
+		return &i.TextInput.FormControl
+	case i.Paragraph != nil:
+		return &i.Paragraph.FormControl
+	case i.Select != nil:
+		return &i.Select.FormControl
+	case i.Number != nil:
+		return &i.Number.FormControl
+	case i.File != nil:
+		return &i.File.FormControl
+	case i.FileList != nil:
+		return &i.FileList.FormControl
+	}
+	return nil
+}
+
+// AppParameters - Features, input parameters and limits of the application.
+type AppParameters struct {
+	OpeningStatement              string              `json:"opening_statement"`                // Opening statement.
+	SuggestedQuestions            []string            `json:"suggested_questions"`              // List of suggested questions for the opening.
+	SuggestedQuestionsAfterAnswer EnabledSetting      `json:"suggested_questions_after_answer"` // Suggest questions after enabling the answer.
+	SpeechToText                  EnabledSetting      `json:"speech_to_text"`                   // Speech to text.
+	TextToSpeech                  TextToSpeechSetting `json:"text_to_speech"`                   // Text to speech.
+	RetrieverResource             EnabledSetting      `json:"retriever_resource"`               // Citation and attribution.
+	AnnotationReply               EnabledSetting      `json:"annotation_reply"`                 // Annotation reply.
+	MoreLikeThis                  EnabledSetting      `json:"more_like_this"`                   // More like this.
+	SensitiveWordAvoidance        EnabledSetting      `json:"sensitive_word_avoidance"`         // Content moderation.
+	UserInputForm                 []UserInputFormItem `json:"user_input_form"`                  // User input form configuration.
+	FileUpload                    FileUploadSetting   `json:"file_upload"`                      // File upload configuration.
+	SystemParameters              SystemParameters    `json:"system_parameters"`                // System parameters.
+}
+
+// ToolIcon - Icon of a tool, either an image URL or an emoji with its background color.
+type ToolIcon struct {
+	URL        string `json:"url,omitempty"`        // Image URL of the icon.
+	Background string `json:"background,omitempty"` // Background color in hex format, for emoji icons.
+	Content    string `json:"content,omitempty"`    // Emoji, for emoji icons.
+}
+
+// UnmarshalJSON - Decodes an icon sent either as a URL string or as an emoji object.
+func (i *ToolIcon) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		*i = ToolIcon{URL: url}
+		return nil
+	}
+
+	type icon ToolIcon
+	var value icon
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal tool icon: %w", err)
+	}
+	*i = ToolIcon(value)
+	return nil
+}
+
+// AppMeta - Meta information of the application.
+type AppMeta struct {
+	ToolIcons map[string]ToolIcon `json:"tool_icons"` // Icons of the tools, by tool name.
+}
+
+// AppSite - WebApp settings of the application.
+type AppSite struct {
+	Title                  string `json:"title"`                     // WebApp name.
+	ChatColorTheme         string `json:"chat_color_theme"`          // Chat color theme, in hex format.
+	ChatColorThemeInverted bool   `json:"chat_color_theme_inverted"` // Whether the chat color theme is inverted.
+	IconType               string `json:"icon_type"`                 // Icon type, `emoji` or `image`.
+	Icon                   string `json:"icon"`                      // Icon, emoji or image ID.
+	IconBackground         string `json:"icon_background"`           // Background color in hex format.
+	IconURL                string `json:"icon_url"`                  // Icon URL, for image icons.
+	Description            string `json:"description"`               // Description.
+	Copyright              string `json:"copyright"`                 // Copyright information.
+	PrivacyPolicy          string `json:"privacy_policy"`            // Privacy policy link.
+	CustomDisclaimer       string `json:"custom_disclaimer"`         // Custom disclaimer.
+	DefaultLanguage        string `json:"default_language"`          // Default language.
+	ShowWorkflowSteps      bool   `json:"show_workflow_steps"`       // Whether to show workflow details.
+	UseIconAsAnswerIcon    bool   `json:"use_icon_as_answer_icon"`   // Whether to replace the bot avatar with the WebApp icon.
+}
+
+// GetAppInfo - Gets the basic information of the application.
+func (c *Client) GetAppInfo(ctx context.Context) (*AppInfo, error) {
+	request, err := c.newRequest(ctx, "GET", appInfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AppInfo
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetAppParameters - Gets the features, input form and limits of the application, to be used before the first message.
+func (c *Client) GetAppParameters(ctx context.Context) (*AppParameters, error) {
+	request, err := c.newRequest(ctx, "GET", appParametersEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AppParameters
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetAppMeta - Gets the meta information of the application, such as the tool icons.
+func (c *Client) GetAppMeta(ctx context.Context) (*AppMeta, error) {
+	request, err := c.newRequest(ctx, "GET", appMetaEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AppMeta
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetAppSite - Gets the WebApp settings of the application.
+func (c *Client) GetAppSite(ctx context.Context) (*AppSite, error) {
+	request, err := c.newRequest(ctx, "GET", appSiteEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AppSite
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
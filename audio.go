@@ -0,0 +1,59 @@
+package dify
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	audioToTextEndpoint = "/v1/audio-to-text" // Endpoint for speech to text.
+	textToAudioEndpoint = "/v1/text-to-audio" // Endpoint for text to speech.
+)
+
+// TextToAudioRequest - Request body for converting text to speech, by message ID or text.
+type TextToAudioRequest struct {
+	MessageID string `json:"message_id,omitempty"` // Message ID, takes priority over Text when both are set.
+	Text      string `json:"text,omitempty"`       // Speech generated content.
+	User      string `json:"user"`                 // Identity of the end user.
+}
+
+// audioToTextResponse - Response body from the AudioToText endpoint.
+type audioToTextResponse struct {
+	Text string `json:"text"` // Output text.
+}
+
+// AudioToText - Converts the audio read from r to text, the filename extension gives the audio format,
+// such as mp3, mp4, mpeg, mpga, m4a, wav or webm.
+func (c *Client) AudioToText(ctx context.Context, user, filename string, r io.Reader) (string, error) {
+	request, err := c.newMultipartRequest(ctx, audioToTextEndpoint, map[string]string{"user": user}, "file", filename, r)
+	if err != nil {
+		return "", err
+	}
+
+	var response audioToTextResponse
+	if err := c.do(request, &response); err != nil {
+		return "", err
+	}
+
+	return response.Text, nil
+}
+
+// TextToAudio - Converts text to speech, returning the audio body, which must be closed by the caller.
+func (c *Client) TextToAudio(ctx context.Context, req TextToAudioRequest) (io.ReadCloser, error) {
+	if req.MessageID == "" && req.Text == "" {
+		return nil, fmt.Errorf("MessageID or Text must be provided")
+	}
+
+	request, err := c.newRequest(ctx, "POST", textToAudioEndpoint, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
@@ -1,12 +1,8 @@
 package dify
 
 import (
-	"bufio"
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 // chatMessageEndpoint - Endpoint for creating a chat message.
@@ -15,7 +11,7 @@ const chatMessageEndpoint = "/v1/chat-messages"
 // ChatMessageRequest - Request body for creating a chat message.
 type ChatMessageRequest struct {
 	Query            string                 `json:"query"`                        // User input/question content.
-	Inputs           map[string]interface{} `json:"inputs"`                       // Variables defined, contains multiple key/value pairs.
+	Inputs           map[string]interface{} `json:"inputs"`                       // Variables defined, contains multiple key/value pairs, file variables take a File or a []File.
 	ResponseMode     ResponseMode           `json:"response_mode"`                // Response mode, `streaming`(recommended) or `blocking`.
 	User             string                 `json:"user"`                         // Identity of the end user.
 	Files            []File                 `json:"files"`                        // Uploaded files.
@@ -25,104 +21,70 @@ type ChatMessageRequest struct {
 
 // CreateChatMessage - Creates a chat message in blocking mode.
 func (c *Client) CreateChatMessage(ctx context.Context, req ChatMessageRequest) (*ChatCompletionResponse, error) {
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, chatMessageEndpoint)
-
-	req.ResponseMode = BlockingMode
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	if err := validateFiles(req.Files, req.Inputs); err != nil {
+		return nil, err
 	}
-
-	request, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+	if err := c.validateChatInputs(ctx, req); err != nil {
+		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	request.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(request)
+	req.ResponseMode = BlockingMode
+	request, err := c.newRequest(ctx, "POST", chatMessageEndpoint, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		buf := &bytes.Buffer{}
-		_, err = buf.ReadFrom(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %v", err)
-		}
-		return nil, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, buf.String())
+		return nil, err
 	}
 
 	var response ChatCompletionResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode response: %v", err)
+	if err := c.do(request, &response); err != nil {
+		return nil, err
 	}
 
 	return &response, nil
 }
 
 // CreateChatMessageStream - Creates a chat message in streaming mode.
-func (c *Client) CreateChatMessageStream(ctx context.Context, req ChatMessageRequest) (<-chan ChunkChatCompletionResponse, error) {
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, chatMessageEndpoint)
-
-	req.ResponseMode = StreamingMode
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %v", err)
+func (c *Client) CreateChatMessageStream(ctx context.Context, req ChatMessageRequest) (*Stream[Event], error) {
+	if err := validateFiles(req.Files, req.Inputs); err != nil {
+		return nil, err
+	}
+	if err := c.validateChatInputs(ctx, req); err != nil {
+		return nil, err
 	}
 
-	request, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	req.ResponseMode = StreamingMode
+	request, err := c.newRequest(ctx, "POST", chatMessageEndpoint, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	request.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(request)
+	resp, err := c.send(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		buf := &bytes.Buffer{}
-		_, err = buf.ReadFrom(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %v", err)
-		}
-		return nil, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, buf.String())
+	stream := newStream(ctx, resp.Body, c.config.MaxEventSize, decodeEvent)
+	stream.stop = func(ctx context.Context, taskID string) error {
+		return c.StopChatMessage(ctx, taskID, req.User)
 	}
 
-	stream := make(chan ChunkChatCompletionResponse)
-	go func() {
-		defer resp.Body.Close()
-		defer close(stream)
-
-		scanner := bufio.NewScanner(resp.Body)
-		for scanner.Scan() {
-			line := scanner.Bytes()
-
-			if bytes.HasPrefix(line, []byte("data: ")) {
-				data := bytes.TrimPrefix(line, []byte("data: "))
-
-				var chunk ChunkChatCompletionResponse
-				if err := json.Unmarshal(data, &chunk); err != nil {
-					fmt.Printf("failed to unmarshal chunk: %v\n", err)
-					return
-				}
+	return stream, nil
+}
 
-				stream <- chunk
-			}
-		}
+// StopChatMessage - Stops the generation of a chat message started in streaming mode.
+func (c *Client) StopChatMessage(ctx context.Context, taskID, user string) error {
+	request, err := c.newRequest(ctx, "POST", fmt.Sprintf("%s/%s/stop", chatMessageEndpoint, taskID), stopRequest{User: user})
+	if err != nil {
+		return err
+	}
 
-		if err := scanner.Err(); err != nil {
-			fmt.Printf("error reading response body: %v\n", err)
-		}
-	}()
+	return c.do(request, nil)
+}
 
-	return stream, nil
+// validateChatInputs - Validates the inputs of the first message of a conversation, Dify ignores the inputs of the
+// following messages, which are usually sent empty.
+func (c *Client) validateChatInputs(ctx context.Context, req ChatMessageRequest) error {
+	if req.ConversationID != "" {
+		return nil
+	}
+	return c.validateInputs(ctx, req.Inputs)
 }
@@ -0,0 +1,42 @@
+package dify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateChatMessageValidateInputs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/parameters":
+			w.Write([]byte(`{"user_input_form":[{"text-input":{"label":"Name","variable":"name","required":true}}]}`))
+		case "/v1/chat-messages":
+			w.Write([]byte(`{"event":"message","task_id":"task-1","message_id":"msg-2","conversation_id":"conv-1","answer":"Hi"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "app-key", ValidateInputs: true})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.CreateChatMessage(context.Background(), ChatMessageRequest{Query: "Hello", User: "user-1"})
+	var validationErr *InputValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("first message error = %v, want *InputValidationError", err)
+	}
+
+	response, err := client.CreateChatMessage(context.Background(), ChatMessageRequest{Query: "Hello again", User: "user-1", ConversationID: "conv-1"})
+	if err != nil {
+		t.Fatalf("follow-up message error = %v", err)
+	}
+	if response.Answer != "Hi" {
+		t.Errorf("Answer = %q, want %q", response.Answer, "Hi")
+	}
+}
@@ -1,20 +1,35 @@
 package dify
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"sync"
 )
 
 // ClientConfig - Configuration for the Dify client.
 type ClientConfig struct {
-	BaseURL string // The base URL of the Dify API.
-	APIKey  string // The API key for authentication.
+	BaseURL        string // The base URL of the Dify API.
+	APIKey         string // The API key for authentication.
+	MaxEventSize   int    // Maximum size in bytes of the lines of a single streamed event, DefaultMaxEventSize when 0.
+	ValidateInputs bool   // Validate request inputs against the user input form of the application before sending, except for follow-up chat messages.
 }
 
 // Client - Dify client for interacting with the API.
 type Client struct {
 	config ClientConfig // Configuration for the client.
 	client *http.Client // HTTP client to send requests.
+
+	mu         sync.Mutex     // Guards parameters.
+	parameters *AppParameters // Parameters of the application, cached for input validation.
 }
 
 // NewClient - Creates and returns a new Dify client.
@@ -27,3 +42,115 @@ func NewClient(config ClientConfig) (*Client, error) {
 		client: &http.Client{},
 	}, nil
 }
+
+// newRequest - Creates an authenticated request for the endpoint path, encoding body as JSON when it is not nil.
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.config.BaseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	return request, nil
+}
+
+// newMultipartRequest - Creates an authenticated multipart request with the form fields and the file read from r.
+// The body is streamed while the request is sent, so the file is never fully buffered in memory.
+func (c *Client) newMultipartRequest(ctx context.Context, path string, fields map[string]string, fileField, filename string, r io.Reader) (*http.Request, error) {
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+
+	request, err := http.NewRequestWithContext(ctx, "POST", c.config.BaseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+
+	// The transport closes the body once the request is done, which also stops the writer on failures.
+	go func() {
+		writer.CloseWithError(writeMultipart(form, fields, fileField, filename, r))
+	}()
+
+	return request, nil
+}
+
+// writeMultipart - Writes the form fields followed by the file part, then the closing boundary.
+func writeMultipart(form *multipart.Writer, fields map[string]string, fileField, filename string, r io.Reader) error {
+	for name, value := range fields {
+		if err := form.WriteField(name, value); err != nil {
+			return fmt.Errorf("failed to write form field %s: %w", name, err)
+		}
+	}
+
+	contentType := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename)))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(fileField), quoteEscaper.Replace(filename)))
+	header.Set("Content-Type", contentType)
+
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return fmt.Errorf("failed to write form file: %w", err)
+	}
+
+	return form.Close()
+}
+
+// quoteEscaper - Escapes quotes and backslashes in the values of the Content-Disposition header.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// send - Sends the request and returns the response, or an *APIError if the status is not successful.
+func (c *Client) send(request *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+
+	return resp, nil
+}
+
+// do - Sends the request and decodes the JSON response body into v, which may be nil to discard it.
+func (c *Client) do(request *http.Request, v interface{}) error {
+	resp, err := c.send(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if v == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
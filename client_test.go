@@ -0,0 +1,133 @@
+package dify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewMultipartRequest(t *testing.T) {
+	file := make([]byte, 3<<20)
+	rand.New(rand.NewSource(1)).Read(file)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer app-key" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer app-key")
+		}
+		form, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("MultipartReader() error = %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		part, err := form.NextPart()
+		if err != nil {
+			t.Errorf("first part error = %v", err)
+			return
+		}
+		value, _ := io.ReadAll(part)
+		if part.FormName() != "user" || string(value) != "user-1" {
+			t.Errorf("first part = %s=%q, want user=%q", part.FormName(), value, "user-1")
+		}
+
+		part, err = form.NextPart()
+		if err != nil {
+			t.Errorf("second part error = %v", err)
+			return
+		}
+		if part.FormName() != "file" || part.FileName() != `report "v2".pdf` {
+			t.Errorf("file part = %s/%s, want file/%s", part.FormName(), part.FileName(), `report "v2".pdf`)
+		}
+		if got := part.Header.Get("Content-Type"); got != "application/pdf" {
+			t.Errorf("file Content-Type = %q, want application/pdf", got)
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			t.Errorf("file read error = %v", err)
+		}
+		if !bytes.Equal(data, file) {
+			t.Errorf("file = %d bytes, want the %d bytes sent", len(data), len(file))
+		}
+
+		if _, err := form.NextPart(); err != io.EOF {
+			t.Errorf("third part error = %v, want io.EOF", err)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "app-key"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	request, err := client.newMultipartRequest(context.Background(), "/v1/files/upload", map[string]string{"user": "user-1"}, "file", `report "v2".pdf`, bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("newMultipartRequest() error = %v", err)
+	}
+	if err := client.do(request, nil); err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+}
+
+// endlessFile - File source writing data until the writer fails, then closing done.
+type endlessFile struct {
+	done chan struct{}
+}
+
+// Read - Never called, io.Copy uses WriteTo.
+func (f *endlessFile) Read(p []byte) (int, error) {
+	return 0, errors.New("unexpected Read")
+}
+
+// WriteTo - Writes chunks until the writer fails.
+func (f *endlessFile) WriteTo(w io.Writer) (int64, error) {
+	defer close(f.done)
+
+	chunk := make([]byte, 32<<10)
+	var n int64
+	for {
+		written, err := w.Write(chunk)
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+}
+
+func TestNewMultipartRequestRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		w.Write([]byte(`{"status":413,"code":"file_too_large","message":"File size exceeded."}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "app-key"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	file := &endlessFile{done: make(chan struct{})}
+	request, err := client.newMultipartRequest(context.Background(), "/v1/files/upload", map[string]string{"user": "user-1"}, "file", "big.bin", file)
+	if err != nil {
+		t.Fatalf("newMultipartRequest() error = %v", err)
+	}
+	var apiErr *APIError
+	if err := client.do(request, nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("do() error = %v, want a 413 *APIError", err)
+	}
+
+	select {
+	case <-file.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("multipart writer still running after the request was rejected")
+	}
+}
@@ -1,12 +1,8 @@
 package dify
 
 import (
-	"bufio"
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 // CompletionMessageEndpoint - Endpoint for creating a completion message.
@@ -14,7 +10,7 @@ const CompletionMessageEndpoint = "/v1/completion-messages"
 
 // CompletionMessageRequest - Request body for creating a completion message.
 type CompletionMessageRequest struct {
-	Inputs       map[string]interface{} `json:"inputs"`        // User input/question content.
+	Inputs       map[string]interface{} `json:"inputs"`        // User input/question content, file variables take a File or a []File.
 	ResponseMode ResponseMode           `json:"response_mode"` // Response mode, `streaming`(recommended) or `blocking`.
 	User         string                 `json:"user"`          // Identity of the end user.
 	Files        []File                 `json:"files"`         // Uploaded files.
@@ -22,104 +18,61 @@ type CompletionMessageRequest struct {
 
 // CreateCompletionMessage - Creates a completion message in blocking mode.
 func (c *Client) CreateCompletionMessage(ctx context.Context, req CompletionMessageRequest) (*ChatCompletionResponse, error) {
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, CompletionMessageEndpoint)
-
-	req.ResponseMode = BlockingMode
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	if err := validateFiles(req.Files, req.Inputs); err != nil {
+		return nil, err
 	}
-
-	request, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+	if err := c.validateInputs(ctx, req.Inputs); err != nil {
+		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	request.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(request)
+	req.ResponseMode = BlockingMode
+	request, err := c.newRequest(ctx, "POST", CompletionMessageEndpoint, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		buf := &bytes.Buffer{}
-		_, err = buf.ReadFrom(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %v", err)
-		}
-		return nil, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, buf.String())
+		return nil, err
 	}
 
 	var response ChatCompletionResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode response: %v", err)
+	if err := c.do(request, &response); err != nil {
+		return nil, err
 	}
 
 	return &response, nil
 }
 
 // CreateCompletionMessageStream - Creates a completion message in streaming mode.
-func (c *Client) CreateCompletionMessageStream(ctx context.Context, req CompletionMessageRequest) (<-chan ChunkChatCompletionResponse, error) {
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, CompletionMessageEndpoint)
-
-	req.ResponseMode = StreamingMode
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %v", err)
+func (c *Client) CreateCompletionMessageStream(ctx context.Context, req CompletionMessageRequest) (*Stream[Event], error) {
+	if err := validateFiles(req.Files, req.Inputs); err != nil {
+		return nil, err
+	}
+	if err := c.validateInputs(ctx, req.Inputs); err != nil {
+		return nil, err
 	}
 
-	request, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	req.ResponseMode = StreamingMode
+	request, err := c.newRequest(ctx, "POST", CompletionMessageEndpoint, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	request.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(request)
+	resp, err := c.send(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		buf := &bytes.Buffer{}
-		_, err = buf.ReadFrom(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %v", err)
-		}
-		return nil, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, buf.String())
+	stream := newStream(ctx, resp.Body, c.config.MaxEventSize, decodeEvent)
+	stream.stop = func(ctx context.Context, taskID string) error {
+		return c.StopCompletionMessage(ctx, taskID, req.User)
 	}
 
-	stream := make(chan ChunkChatCompletionResponse)
-	go func() {
-		defer resp.Body.Close()
-		defer close(stream)
-
-		scanner := bufio.NewScanner(resp.Body)
-		for scanner.Scan() {
-			line := scanner.Bytes()
-
-			if bytes.HasPrefix(line, []byte("data: ")) {
-				data := bytes.TrimPrefix(line, []byte("data: "))
-
-				var chunk ChunkChatCompletionResponse
-				if err := json.Unmarshal(data, &chunk); err != nil {
-					fmt.Printf("failed to unmarshal chunk: %v\n", err)
-					return
-				}
-
-				stream <- chunk
-			}
-		}
+	return stream, nil
+}
 
-		if err := scanner.Err(); err != nil {
-			fmt.Printf("error reading response body: %v\n", err)
-		}
-	}()
+// StopCompletionMessage - Stops the generation of a completion message started in streaming mode.
+func (c *Client) StopCompletionMessage(ctx context.Context, taskID, user string) error {
+	request, err := c.newRequest(ctx, "POST", fmt.Sprintf("%s/%s/stop", CompletionMessageEndpoint, taskID), stopRequest{User: user})
+	if err != nil {
+		return err
+	}
 
-	return stream, nil
+	return c.do(request, nil)
 }
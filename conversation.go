@@ -0,0 +1,199 @@
+package dify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	conversationEndpoint = "/v1/conversations" // Endpoint for conversations.
+	messageEndpoint      = "/v1/messages"      // Endpoint for messages.
+)
+
+// Conversation - Conversation of an end user.
+type Conversation struct {
+	ID           string                 `json:"id"`           // Conversation ID.
+	Name         string                 `json:"name"`         // Conversation name, generated by the model by default.
+	Inputs       map[string]interface{} `json:"inputs"`       // Variables of the conversation.
+	Status       string                 `json:"status"`       // Conversation status.
+	Introduction string                 `json:"introduction"` // Opening statement.
+	CreatedAt    int                    `json:"created_at"`   // Creation timestamp, such as: 1705395332.
+	UpdatedAt    int                    `json:"updated_at"`   // Update timestamp, such as: 1705395332.
+}
+
+// MessageFeedback - Feedback given to a message.
+type MessageFeedback struct {
+	Rating Rating `json:"rating"` // Rating, `like` or `dislike`.
+}
+
+// Message - Message of a conversation history.
+type Message struct {
+	ID                 string                 `json:"id"`                            // Message ID.
+	ConversationID     string                 `json:"conversation_id"`               // Conversation ID.
+	Inputs             map[string]interface{} `json:"inputs"`                        // Variables of the message.
+	Query              string                 `json:"query"`                         // User input/question content.
+	Answer             string                 `json:"answer"`                        // Response content.
+	MessageFiles       []MessageFile          `json:"message_files,omitempty"`       // Files of the message.
+	Feedback           *MessageFeedback       `json:"feedback,omitempty"`            // Feedback of the message, nil if none.
+	RetrieverResources []RetrieverResource    `json:"retriever_resources,omitempty"` // List of references and attributed segments.
+	AgentThoughts      []AgentThought         `json:"agent_thoughts,omitempty"`      // Agent thoughts, empty if the app is not an Agent.
+	CreatedAt          int                    `json:"created_at"`                    // Creation timestamp, such as: 1705395332.
+}
+
+// ListConversationsRequest - Request parameters for listing the conversations of a user.
+type ListConversationsRequest struct {
+	User   string // Identity of the end user.
+	LastID string // ID of the last record on the current page, empty for the first page.
+	Limit  int    // Number of records to return, 20 by default.
+	SortBy string // Sorting field, `created_at`, `-created_at`, `updated_at` or `-updated_at` (default).
+	Pinned *bool  // Only return pinned or unpinned conversations, all by default.
+}
+
+// ConversationsResponse - Response body from the ListConversations endpoint.
+type ConversationsResponse struct {
+	Limit   int            `json:"limit"`    // Number of records per page.
+	HasMore bool           `json:"has_more"` // Whether there is a next page.
+	Data    []Conversation `json:"data"`     // List of conversations.
+}
+
+// ListMessagesRequest - Request parameters for listing the messages of a conversation.
+type ListMessagesRequest struct {
+	ConversationID string // Conversation ID.
+	User           string // Identity of the end user.
+	FirstID        string // ID of the first message on the current page, empty for the latest messages.
+	Limit          int    // Number of messages to return, 20 by default.
+}
+
+// MessagesResponse - Response body from the ListMessages endpoint.
+type MessagesResponse struct {
+	Limit   int       `json:"limit"`    // Number of records per page.
+	HasMore bool      `json:"has_more"` // Whether there are earlier messages.
+	Data    []Message `json:"data"`     // List of messages, in chronological order.
+}
+
+// RenameConversationRequest - Request body for renaming a conversation.
+type RenameConversationRequest struct {
+	Name         string `json:"name,omitempty"`          // Conversation name, can be empty when AutoGenerate is true.
+	AutoGenerate bool   `json:"auto_generate,omitempty"` // Automatically generate the conversation name.
+	User         string `json:"user"`                    // Identity of the end user.
+}
+
+// deleteConversationRequest - Request body for deleting a conversation.
+type deleteConversationRequest struct {
+	User string `json:"user"` // Identity of the end user.
+}
+
+// ListConversations - Lists the conversations of a user, the most recently updated first by default.
+func (c *Client) ListConversations(ctx context.Context, req ListConversationsRequest) (*ConversationsResponse, error) {
+	query := url.Values{}
+	query.Set("user", req.User)
+	if req.LastID != "" {
+		query.Set("last_id", req.LastID)
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+	if req.SortBy != "" {
+		query.Set("sort_by", req.SortBy)
+	}
+	if req.Pinned != nil {
+		query.Set("pinned", strconv.FormatBool(*req.Pinned))
+	}
+
+	request, err := c.newRequest(ctx, "GET", conversationEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ConversationsResponse
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListMessages - Lists the messages of a conversation page by page, from the latest to the earliest.
+func (c *Client) ListMessages(ctx context.Context, req ListMessagesRequest) (*MessagesResponse, error) {
+	query := url.Values{}
+	query.Set("conversation_id", req.ConversationID)
+	query.Set("user", req.User)
+	if req.FirstID != "" {
+		query.Set("first_id", req.FirstID)
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	request, err := c.newRequest(ctx, "GET", messageEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response MessagesResponse
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// RenameConversation - Renames a conversation, or generates its name automatically.
+func (c *Client) RenameConversation(ctx context.Context, conversationID string, req RenameConversationRequest) (*Conversation, error) {
+	request, err := c.newRequest(ctx, "POST", fmt.Sprintf("%s/%s/name", conversationEndpoint, conversationID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response Conversation
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DeleteConversation - Deletes a conversation.
+func (c *Client) DeleteConversation(ctx context.Context, conversationID, user string) error {
+	request, err := c.newRequest(ctx, "DELETE", fmt.Sprintf("%s/%s", conversationEndpoint, conversationID), deleteConversationRequest{User: user})
+	if err != nil {
+		return err
+	}
+
+	return c.do(request, nil)
+}
+
+// Conversations - Returns a pager over all the conversations of a user, fetching req.Limit conversations per page.
+func (c *Client) Conversations(ctx context.Context, req ListConversationsRequest) *Pager[Conversation] {
+	return newPager(ctx, func(ctx context.Context, previous []Conversation) ([]Conversation, bool, error) {
+		if len(previous) > 0 {
+			req.LastID = previous[len(previous)-1].ID
+		}
+
+		response, err := c.ListConversations(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response.Data, response.HasMore, nil
+	})
+}
+
+// Messages - Returns a pager over the messages of a conversation, fetching req.Limit messages per page.
+// Pages go from the latest messages to the earliest, while the messages of a page are in chronological order.
+func (c *Client) Messages(ctx context.Context, req ListMessagesRequest) *Pager[Message] {
+	return newPager(ctx, func(ctx context.Context, previous []Message) ([]Message, bool, error) {
+		if len(previous) > 0 {
+			req.FirstID = previous[0].ID
+		}
+
+		response, err := c.ListMessages(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response.Data, response.HasMore, nil
+	})
+}
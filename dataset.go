@@ -0,0 +1,288 @@
+package dify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// datasetEndpoint - Endpoint for knowledge bases.
+const datasetEndpoint = "/v1/datasets"
+
+const (
+	IndexingTechniqueHighQuality IndexingTechnique = "high_quality" // Embedding model and vector index.
+	IndexingTechniqueEconomy     IndexingTechnique = "economy"      // Keyword index.
+)
+
+// IndexingTechnique - Indexing technique of a knowledge base, `high_quality` or `economy`.
+type IndexingTechnique string
+
+const (
+	DatasetPermissionOnlyMe         DatasetPermission = "only_me"          // Only the creator.
+	DatasetPermissionAllTeamMembers DatasetPermission = "all_team_members" // All the members of the team.
+	DatasetPermissionPartialMembers DatasetPermission = "partial_members"  // Members of the partial member list.
+)
+
+// DatasetPermission - Visibility of a knowledge base, `only_me`, `all_team_members` or `partial_members`.
+type DatasetPermission string
+
+const (
+	SearchMethodKeyword  SearchMethod = "keyword_search"   // Keyword search, for the economy indexing technique.
+	SearchMethodSemantic SearchMethod = "semantic_search"  // Vector search.
+	SearchMethodFullText SearchMethod = "full_text_search" // Full text search.
+	SearchMethodHybrid   SearchMethod = "hybrid_search"    // Vector and full text search.
+)
+
+// SearchMethod - Retrieval method of a knowledge base.
+type SearchMethod string
+
+const (
+	RerankingModeModel         RerankingMode = "reranking_model" // Rerank with a rerank model.
+	RerankingModeWeightedScore RerankingMode = "weighted_score"  // Rerank with the weights of the vector and keyword scores.
+)
+
+// RerankingMode - Reranking mode of a hybrid search, `reranking_model` or `weighted_score`.
+type RerankingMode string
+
+// RerankingModel - Rerank model of a retrieval.
+type RerankingModel struct {
+	RerankingProviderName string `json:"reranking_provider_name"` // Provider of the rerank model.
+	RerankingModelName    string `json:"reranking_model_name"`    // Name of the rerank model.
+}
+
+// VectorSetting - Weight of the vector search in a weighted score reranking.
+type VectorSetting struct {
+	VectorWeight          float64 `json:"vector_weight"`           // Weight of the semantic score.
+	EmbeddingProviderName string  `json:"embedding_provider_name"` // Provider of the embedding model.
+	EmbeddingModelName    string  `json:"embedding_model_name"`    // Name of the embedding model.
+}
+
+// KeywordSetting - Weight of the keyword search in a weighted score reranking.
+type KeywordSetting struct {
+	KeywordWeight float64 `json:"keyword_weight"` // Weight of the keyword score.
+}
+
+// RetrievalWeights - Weights of a weighted score reranking.
+type RetrievalWeights struct {
+	WeightType     string         `json:"weight_type,omitempty"` // Weight preset, such as `customized`.
+	VectorSetting  VectorSetting  `json:"vector_setting"`        // Vector search weight.
+	KeywordSetting KeywordSetting `json:"keyword_setting"`       // Keyword search weight.
+}
+
+// RetrievalModel - Retrieval settings of a knowledge base.
+type RetrievalModel struct {
+	SearchMethod          SearchMethod      `json:"search_method"`             // Retrieval method.
+	RerankingEnable       bool              `json:"reranking_enable"`          // Whether reranking is enabled.
+	RerankingMode         RerankingMode     `json:"reranking_mode,omitempty"`  // Reranking mode, for hybrid searches.
+	RerankingModel        *RerankingModel   `json:"reranking_model,omitempty"` // Rerank model, when reranking with a model.
+	Weights               *RetrievalWeights `json:"weights,omitempty"`         // Weights, when reranking with weighted scores.
+	TopK                  int               `json:"top_k"`                     // Number of segments returned.
+	ScoreThresholdEnabled bool              `json:"score_threshold_enabled"`   // Whether the score threshold is enabled.
+	ScoreThreshold        float64           `json:"score_threshold,omitempty"` // Minimum score of the segments returned.
+}
+
+// ExternalKnowledgeInfo - Binding of an external knowledge base.
+type ExternalKnowledgeInfo struct {
+	ExternalKnowledgeID          string `json:"external_knowledge_id"`           // ID of the knowledge base in the external system.
+	ExternalKnowledgeAPIID       string `json:"external_knowledge_api_id"`       // ID of the external knowledge API.
+	ExternalKnowledgeAPIName     string `json:"external_knowledge_api_name"`     // Name of the external knowledge API.
+	ExternalKnowledgeAPIEndpoint string `json:"external_knowledge_api_endpoint"` // Endpoint of the external knowledge API.
+}
+
+// DatasetTag - Tag of a knowledge base.
+type DatasetTag struct {
+	ID   string `json:"id"`   // Tag ID.
+	Name string `json:"name"` // Tag name.
+	Type string `json:"type"` // Tag type, `knowledge`.
+}
+
+// DatasetMember - Member of the partial member list of a knowledge base.
+type DatasetMember struct {
+	UserID string `json:"user_id"` // Account ID of the member.
+}
+
+// Dataset - Knowledge base.
+type Dataset struct {
+	ID                     string                 `json:"id"`                                // Knowledge base ID.
+	Name                   string                 `json:"name"`                              // Knowledge base name.
+	Description            string                 `json:"description"`                       // Knowledge base description.
+	Provider               string                 `json:"provider"`                          // Provider, `vendor` or `external`.
+	Permission             DatasetPermission      `json:"permission"`                        // Visibility.
+	DataSourceType         string                 `json:"data_source_type"`                  // Data source type, such as `upload_file`.
+	IndexingTechnique      IndexingTechnique      `json:"indexing_technique"`                // Indexing technique.
+	AppCount               int                    `json:"app_count"`                         // Number of applications using the knowledge base.
+	DocumentCount          int                    `json:"document_count"`                    // Number of documents.
+	WordCount              int                    `json:"word_count"`                        // Number of words.
+	CreatedBy              string                 `json:"created_by"`                        // Account ID of the creator.
+	CreatedAt              int                    `json:"created_at"`                        // Creation timestamp.
+	UpdatedBy              string                 `json:"updated_by"`                        // Account ID of the last editor.
+	UpdatedAt              int                    `json:"updated_at"`                        // Update timestamp.
+	EmbeddingModel         string                 `json:"embedding_model"`                   // Name of the embedding model.
+	EmbeddingModelProvider string                 `json:"embedding_model_provider"`          // Provider of the embedding model.
+	EmbeddingAvailable     bool                   `json:"embedding_available"`               // Whether the embedding model is available.
+	RetrievalModel         RetrievalModel         `json:"retrieval_model_dict"`              // Retrieval settings.
+	Tags                   []DatasetTag           `json:"tags"`                              // Tags.
+	DocForm                DocForm                `json:"doc_form"`                          // Chunk structure of the documents.
+	ExternalKnowledgeInfo  *ExternalKnowledgeInfo `json:"external_knowledge_info,omitempty"` // External knowledge binding, for external knowledge bases.
+	PartialMemberList      []string               `json:"partial_member_list,omitempty"`     // Account IDs of the members, for `partial_members`.
+}
+
+// CreateDatasetRequest - Request body for creating a knowledge base.
+type CreateDatasetRequest struct {
+	Name                   string            `json:"name"`                                // Knowledge base name.
+	Description            string            `json:"description,omitempty"`               // Optional Knowledge base description.
+	IndexingTechnique      IndexingTechnique `json:"indexing_technique,omitempty"`        // Optional Indexing technique, required before adding documents.
+	Permission             DatasetPermission `json:"permission,omitempty"`                // Optional Visibility, `only_me` by default.
+	Provider               string            `json:"provider,omitempty"`                  // Optional `vendor` by default, or `external` for an external knowledge base.
+	ExternalKnowledgeAPIID string            `json:"external_knowledge_api_id,omitempty"` // Optional External knowledge API ID, for external knowledge bases.
+	ExternalKnowledgeID    string            `json:"external_knowledge_id,omitempty"`     // Optional External knowledge ID, for external knowledge bases.
+	EmbeddingModel         string            `json:"embedding_model,omitempty"`           // Optional Name of the embedding model.
+	EmbeddingModelProvider string            `json:"embedding_model_provider,omitempty"`  // Optional Provider of the embedding model.
+	RetrievalModel         *RetrievalModel   `json:"retrieval_model,omitempty"`           // Optional Retrieval settings.
+	PartialMemberList      []DatasetMember   `json:"partial_member_list,omitempty"`       // Optional Members, for `partial_members`.
+}
+
+// UpdateDatasetRequest - Request body for updating a knowledge base, only the fields set are updated.
+type UpdateDatasetRequest struct {
+	Name                   string            `json:"name,omitempty"`                     // Optional Knowledge base name.
+	Description            *string           `json:"description,omitempty"`              // Optional Knowledge base description, may be set to empty.
+	IndexingTechnique      IndexingTechnique `json:"indexing_technique,omitempty"`       // Optional Indexing technique.
+	Permission             DatasetPermission `json:"permission,omitempty"`               // Optional Visibility.
+	EmbeddingModel         string            `json:"embedding_model,omitempty"`          // Optional Name of the embedding model.
+	EmbeddingModelProvider string            `json:"embedding_model_provider,omitempty"` // Optional Provider of the embedding model.
+	RetrievalModel         *RetrievalModel   `json:"retrieval_model,omitempty"`          // Optional Retrieval settings.
+	PartialMemberList      []DatasetMember   `json:"partial_member_list,omitempty"`      // Optional Members, for `partial_members`.
+}
+
+// ListDatasetsRequest - Request parameters for listing the knowledge bases.
+type ListDatasetsRequest struct {
+	Keyword    string   // Optional Keyword to search in the names.
+	TagIDs     []string // Optional Only the knowledge bases with these tags.
+	IncludeAll bool     // Optional Include all the knowledge bases of the workspace, for workspace owners.
+	Page       int      // Page number, 1 by default.
+	Limit      int      // Number of records per page, 20 by default.
+}
+
+// DatasetsResponse - Response body from the ListDatasets endpoint.
+type DatasetsResponse struct {
+	Data    []Dataset `json:"data"`     // List of knowledge bases.
+	HasMore bool      `json:"has_more"` // Whether there is a next page.
+	Limit   int       `json:"limit"`    // Number of records per page.
+	Total   int       `json:"total"`    // Total number of records.
+	Page    int       `json:"page"`     // Current page.
+}
+
+// DatasetClient - Dify client for the knowledge base API, authenticated with a knowledge base API key.
+type DatasetClient struct {
+	client *Client // Client sending the requests.
+}
+
+// NewDatasetClient - Creates and returns a new Dify knowledge base client.
+func NewDatasetClient(config ClientConfig) (*DatasetClient, error) {
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &DatasetClient{client: client}, nil
+}
+
+// CreateDataset - Creates an empty knowledge base.
+func (c *DatasetClient) CreateDataset(ctx context.Context, req CreateDatasetRequest) (*Dataset, error) {
+	request, err := c.client.newRequest(ctx, "POST", datasetEndpoint, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response Dataset
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListDatasets - Lists the knowledge bases, the most recent first.
+func (c *DatasetClient) ListDatasets(ctx context.Context, req ListDatasetsRequest) (*DatasetsResponse, error) {
+	query := url.Values{}
+	if req.Keyword != "" {
+		query.Set("keyword", req.Keyword)
+	}
+	for _, tagID := range req.TagIDs {
+		query.Add("tag_ids", tagID)
+	}
+	if req.IncludeAll {
+		query.Set("include_all", "true")
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	request, err := c.client.newRequest(ctx, "GET", datasetEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DatasetsResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Datasets - Returns a pager over the knowledge bases from req.Page, fetching req.Limit knowledge bases per page.
+func (c *DatasetClient) Datasets(ctx context.Context, req ListDatasetsRequest) *Pager[Dataset] {
+	return newPagePager(ctx, req.Page, func(ctx context.Context, page int) ([]Dataset, bool, error) {
+		req.Page = page
+		response, err := c.ListDatasets(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response.Data, response.HasMore, nil
+	})
+}
+
+// GetDataset - Gets the details of a knowledge base.
+func (c *DatasetClient) GetDataset(ctx context.Context, datasetID string) (*Dataset, error) {
+	request, err := c.client.newRequest(ctx, "GET", fmt.Sprintf("%s/%s", datasetEndpoint, datasetID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response Dataset
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// UpdateDataset - Updates the name, settings, retrieval model or members of a knowledge base.
+func (c *DatasetClient) UpdateDataset(ctx context.Context, datasetID string, req UpdateDatasetRequest) (*Dataset, error) {
+	request, err := c.client.newRequest(ctx, "PATCH", fmt.Sprintf("%s/%s", datasetEndpoint, datasetID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response Dataset
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DeleteDataset - Deletes a knowledge base, which must not be used by any application.
+func (c *DatasetClient) DeleteDataset(ctx context.Context, datasetID string) error {
+	request, err := c.client.newRequest(ctx, "DELETE", fmt.Sprintf("%s/%s", datasetEndpoint, datasetID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.client.do(request, nil)
+}
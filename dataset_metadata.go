@@ -0,0 +1,138 @@
+package dify
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	MetadataTypeString MetadataType = "string" // Text value.
+	MetadataTypeNumber MetadataType = "number" // Numeric value.
+	MetadataTypeTime   MetadataType = "time"   // Timestamp value.
+)
+
+// MetadataType - Value type of a metadata field, `string`, `number` or `time`.
+type MetadataType string
+
+// MetadataField - Metadata field of a knowledge base.
+type MetadataField struct {
+	ID    string       `json:"id"`              // Metadata field ID.
+	Name  string       `json:"name"`            // Metadata field name.
+	Type  MetadataType `json:"type"`            // Value type.
+	Count int          `json:"count,omitempty"` // Number of documents using the field, when listed.
+}
+
+// DatasetMetadata - Metadata fields of a knowledge base.
+type DatasetMetadata struct {
+	DocMetadata         []MetadataField `json:"doc_metadata"`           // Custom and built-in metadata fields.
+	BuiltInFieldEnabled bool            `json:"built_in_field_enabled"` // Whether the built-in fields are filled for the documents.
+}
+
+// CreateMetadataRequest - Request body for creating a metadata field.
+type CreateMetadataRequest struct {
+	Type MetadataType `json:"type"` // Value type.
+	Name string       `json:"name"` // Metadata field name.
+}
+
+// updateMetadataRequest - Request body for renaming a metadata field.
+type updateMetadataRequest struct {
+	Name string `json:"name"` // New name of the metadata field.
+}
+
+// MetadataValue - Value of a metadata field for a document.
+type MetadataValue struct {
+	ID    string      `json:"id"`    // Metadata field ID.
+	Name  string      `json:"name"`  // Metadata field name.
+	Value interface{} `json:"value"` // Value of the document, nil to clear it.
+}
+
+// DocumentMetadataUpdate - Metadata values of a document, replacing its previous values.
+type DocumentMetadataUpdate struct {
+	DocumentID   string          `json:"document_id"`   // Document ID.
+	MetadataList []MetadataValue `json:"metadata_list"` // Values of the document.
+}
+
+// documentMetadataRequest - Request body for updating the metadata values of documents.
+type documentMetadataRequest struct {
+	OperationData []DocumentMetadataUpdate `json:"operation_data"` // Values, by document.
+}
+
+// CreateMetadata - Creates a metadata field in a knowledge base.
+func (c *DatasetClient) CreateMetadata(ctx context.Context, datasetID string, req CreateMetadataRequest) (*MetadataField, error) {
+	request, err := c.client.newRequest(ctx, "POST", fmt.Sprintf("%s/%s/metadata", datasetEndpoint, datasetID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response MetadataField
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListMetadata - Lists the metadata fields of a knowledge base, with the number of documents using each one.
+func (c *DatasetClient) ListMetadata(ctx context.Context, datasetID string) (*DatasetMetadata, error) {
+	request, err := c.client.newRequest(ctx, "GET", fmt.Sprintf("%s/%s/metadata", datasetEndpoint, datasetID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DatasetMetadata
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// UpdateMetadata - Renames a metadata field of a knowledge base.
+func (c *DatasetClient) UpdateMetadata(ctx context.Context, datasetID, metadataID, name string) (*MetadataField, error) {
+	request, err := c.client.newRequest(ctx, "PATCH", fmt.Sprintf("%s/%s/metadata/%s", datasetEndpoint, datasetID, metadataID), updateMetadataRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var response MetadataField
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DeleteMetadata - Deletes a metadata field of a knowledge base, with its values in the documents.
+func (c *DatasetClient) DeleteMetadata(ctx context.Context, datasetID, metadataID string) error {
+	request, err := c.client.newRequest(ctx, "DELETE", fmt.Sprintf("%s/%s/metadata/%s", datasetEndpoint, datasetID, metadataID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.client.do(request, nil)
+}
+
+// SetBuiltInMetadata - Enables or disables the built-in metadata fields, such as document_name and upload_date.
+func (c *DatasetClient) SetBuiltInMetadata(ctx context.Context, datasetID string, enabled bool) error {
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+
+	request, err := c.client.newRequest(ctx, "POST", fmt.Sprintf("%s/%s/metadata/built-in/%s", datasetEndpoint, datasetID, action), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.client.do(request, nil)
+}
+
+// UpdateDocumentMetadata - Sets the metadata values of documents of a knowledge base in one call.
+func (c *DatasetClient) UpdateDocumentMetadata(ctx context.Context, datasetID string, updates []DocumentMetadataUpdate) error {
+	request, err := c.client.newRequest(ctx, "POST", fmt.Sprintf("%s/%s/documents/metadata", datasetEndpoint, datasetID), documentMetadataRequest{OperationData: updates})
+	if err != nil {
+		return err
+	}
+
+	return c.client.do(request, nil)
+}
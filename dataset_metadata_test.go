@@ -0,0 +1,62 @@
+package dify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDatasetMetadata(t *testing.T) {
+	var requests []string
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/datasets/ds-1/metadata":
+			w.Write([]byte(`{"doc_metadata":[{"id":"md-1","name":"author","type":"string","count":3}],"built_in_field_enabled":true}`))
+		case "POST /v1/datasets/ds-1/documents/metadata":
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			w.Write([]byte(`{"result":"success"}`))
+		case "POST /v1/datasets/ds-1/metadata/built-in/disable":
+			w.Write([]byte(`{"result":"success"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewDatasetClient(ClientConfig{BaseURL: server.URL, APIKey: "dataset-key"})
+	if err != nil {
+		t.Fatalf("NewDatasetClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	metadata, err := client.ListMetadata(ctx, "ds-1")
+	if err != nil {
+		t.Fatalf("ListMetadata() error = %v", err)
+	}
+	want := MetadataField{ID: "md-1", Name: "author", Type: MetadataTypeString, Count: 3}
+	if !metadata.BuiltInFieldEnabled || len(metadata.DocMetadata) != 1 || metadata.DocMetadata[0] != want {
+		t.Errorf("ListMetadata() = %+v, want %+v with built-in fields", metadata, want)
+	}
+
+	err = client.UpdateDocumentMetadata(ctx, "ds-1", []DocumentMetadataUpdate{
+		{DocumentID: "doc-1", MetadataList: []MetadataValue{{ID: "md-1", Name: "author", Value: "Ann"}}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateDocumentMetadata() error = %v", err)
+	}
+	if wantBody := `{"operation_data":[{"document_id":"doc-1","metadata_list":[{"id":"md-1","name":"author","value":"Ann"}]}]}`; body != wantBody {
+		t.Errorf("UpdateDocumentMetadata() body = %s, want %s", body, wantBody)
+	}
+
+	if err := client.SetBuiltInMetadata(ctx, "ds-1", false); err != nil {
+		t.Fatalf("SetBuiltInMetadata() error = %v", err)
+	}
+	if n := len(requests); n != 3 {
+		t.Errorf("requests = %v, want 3", requests)
+	}
+}
@@ -0,0 +1,332 @@
+package dify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+const (
+	DocFormText         DocForm = "text_model"         // General chunks.
+	DocFormHierarchical DocForm = "hierarchical_model" // Parent-child chunks.
+	DocFormQA           DocForm = "qa_model"           // Question and answer chunks.
+)
+
+// DocForm - Chunk structure of the documents, `text_model`, `hierarchical_model` or `qa_model`.
+type DocForm string
+
+const (
+	ProcessRuleModeAutomatic    ProcessRuleMode = "automatic"    // Automatic cleaning and segmentation.
+	ProcessRuleModeCustom       ProcessRuleMode = "custom"       // Custom rules.
+	ProcessRuleModeHierarchical ProcessRuleMode = "hierarchical" // Custom rules with parent-child chunks.
+)
+
+// ProcessRuleMode - Cleaning and segmentation mode, `automatic`, `custom` or `hierarchical`.
+type ProcessRuleMode string
+
+const (
+	PreProcessingRemoveExtraSpaces = "remove_extra_spaces" // Replace consecutive spaces, newlines and tabs.
+	PreProcessingRemoveURLsEmails  = "remove_urls_emails"  // Delete URLs and email addresses.
+)
+
+const (
+	ParentModeFullDoc   ParentMode = "full-doc"  // The whole document is the parent chunk.
+	ParentModeParagraph ParentMode = "paragraph" // Each segment is a parent chunk.
+)
+
+// ParentMode - Parent chunk retrieval mode of parent-child chunks, `full-doc` or `paragraph`.
+type ParentMode string
+
+// PreProcessingRule - Text cleaning rule.
+type PreProcessingRule struct {
+	ID      string `json:"id"`      // Rule ID, such as PreProcessingRemoveExtraSpaces.
+	Enabled bool   `json:"enabled"` // Whether the rule is applied.
+}
+
+// Segmentation - Chunking settings.
+type Segmentation struct {
+	Separator    string `json:"separator"`               // Segment separator, such as `\n`.
+	MaxTokens    int    `json:"max_tokens"`              // Maximum length of a chunk (tokens).
+	ChunkOverlap int    `json:"chunk_overlap,omitempty"` // Optional Overlap between consecutive chunks (tokens).
+}
+
+// ProcessRules - Custom cleaning and segmentation rules.
+type ProcessRules struct {
+	PreProcessingRules   []PreProcessingRule `json:"pre_processing_rules"`            // Text cleaning rules.
+	Segmentation         Segmentation        `json:"segmentation"`                    // Chunking, of the parent chunks in parent-child mode.
+	ParentMode           ParentMode          `json:"parent_mode,omitempty"`           // Parent chunk mode, for the hierarchical mode.
+	SubchunkSegmentation *Segmentation       `json:"subchunk_segmentation,omitempty"` // Chunking of the child chunks, for the hierarchical mode.
+}
+
+// ProcessRule - Cleaning and segmentation of a document.
+type ProcessRule struct {
+	Mode  ProcessRuleMode `json:"mode"`            // Cleaning and segmentation mode.
+	Rules *ProcessRules   `json:"rules,omitempty"` // Custom rules, nil in automatic mode.
+}
+
+// Document - Document of a knowledge base.
+type Document struct {
+	ID                   string                 `json:"id"`                               // Document ID.
+	Position             int                    `json:"position"`                         // Position in the knowledge base.
+	DataSourceType       string                 `json:"data_source_type"`                 // Data source type, such as `upload_file`.
+	DataSourceInfo       map[string]interface{} `json:"data_source_info"`                 // Data source details, such as the upload file ID.
+	DatasetProcessRuleID string                 `json:"dataset_process_rule_id"`          // ID of the process rule.
+	Name                 string                 `json:"name"`                             // Document name.
+	CreatedFrom          string                 `json:"created_from"`                     // Source of the document, such as `api` or `web`.
+	CreatedBy            string                 `json:"created_by"`                       // Account ID of the creator.
+	CreatedAt            int                    `json:"created_at"`                       // Creation timestamp.
+	Tokens               int                    `json:"tokens"`                           // Number of tokens.
+	IndexingStatus       string                 `json:"indexing_status"`                  // Indexing status, such as `waiting`, `indexing` or `completed`.
+	Error                string                 `json:"error"`                            // The reason for the indexing error.
+	Enabled              bool                   `json:"enabled"`                          // Whether the document is used for retrieval.
+	DisabledAt           int                    `json:"disabled_at"`                      // Timestamp of the disabling, 0 if enabled.
+	DisabledBy           string                 `json:"disabled_by"`                      // Account ID of who disabled the document.
+	Archived             bool                   `json:"archived"`                         // Whether the document is archived.
+	DisplayStatus        string                 `json:"display_status"`                   // Status shown in the console, such as `available`.
+	WordCount            int                    `json:"word_count"`                       // Number of words.
+	HitCount             int                    `json:"hit_count"`                        // Number of retrievals.
+	DocForm              DocForm                `json:"doc_form"`                         // Chunk structure.
+	UpdatedAt            int                    `json:"updated_at,omitempty"`             // Update timestamp, in the document detail.
+	SegmentCount         int                    `json:"segment_count,omitempty"`          // Number of segments, in the document detail.
+	AverageSegmentLength int                    `json:"average_segment_length,omitempty"` // Average length of the segments, in the document detail.
+	DocMetadata          []DocumentMetadata     `json:"doc_metadata,omitempty"`           // Metadata values of the document.
+}
+
+// DocumentMetadata - Metadata value of a document.
+type DocumentMetadata struct {
+	ID    string       `json:"id"`    // Metadata field ID.
+	Name  string       `json:"name"`  // Metadata field name.
+	Type  MetadataType `json:"type"`  // Metadata field type.
+	Value interface{}  `json:"value"` // Value of the document.
+}
+
+// DocumentUploadFile - File a document was created from.
+type DocumentUploadFile struct {
+	UploadedFile
+	URL         string `json:"url"`          // Preview URL of the file.
+	DownloadURL string `json:"download_url"` // Download URL of the file.
+}
+
+const (
+	DocumentActionEnable    DocumentAction = "enable"     // Use the documents for retrieval.
+	DocumentActionDisable   DocumentAction = "disable"    // Stop using the documents for retrieval.
+	DocumentActionArchive   DocumentAction = "archive"    // Archive the documents, which can no longer be edited.
+	DocumentActionUnarchive DocumentAction = "un_archive" // Restore archived documents.
+)
+
+// DocumentAction - Batch status change of documents.
+type DocumentAction string
+
+// ListDocumentsRequest - Request parameters for listing the documents of a knowledge base.
+type ListDocumentsRequest struct {
+	Keyword string // Optional Keyword to search in the names.
+	Page    int    // Page number, 1 by default.
+	Limit   int    // Number of records per page, 20 by default.
+}
+
+// DocumentsResponse - Response body from the ListDocuments endpoint.
+type DocumentsResponse struct {
+	Data    []Document `json:"data"`     // List of documents.
+	HasMore bool       `json:"has_more"` // Whether there is a next page.
+	Limit   int        `json:"limit"`    // Number of records per page.
+	Total   int        `json:"total"`    // Total number of records.
+	Page    int        `json:"page"`     // Current page.
+}
+
+// documentStatusRequest - Request body for changing the status of documents.
+type documentStatusRequest struct {
+	DocumentIDs []string `json:"document_ids"` // IDs of the documents.
+}
+
+// DocumentResponse - Response body from the document creation and update endpoints.
+type DocumentResponse struct {
+	Document Document `json:"document"` // Document created or updated.
+	Batch    string   `json:"batch"`    // Indexing batch ID, used to follow the indexing.
+}
+
+// CreateDocumentByTextRequest - Request body for creating a document from text.
+type CreateDocumentByTextRequest struct {
+	Name                   string            `json:"name"`                               // Document name.
+	Text                   string            `json:"text"`                               // Document content.
+	IndexingTechnique      IndexingTechnique `json:"indexing_technique,omitempty"`       // Indexing technique, required for the first document of a knowledge base.
+	DocForm                DocForm           `json:"doc_form,omitempty"`                 // Optional Chunk structure, `text_model` by default.
+	DocLanguage            string            `json:"doc_language,omitempty"`             // Optional Document language, for `qa_model`, such as `English`.
+	ProcessRule            ProcessRule       `json:"process_rule"`                       // Cleaning and segmentation.
+	RetrievalModel         *RetrievalModel   `json:"retrieval_model,omitempty"`          // Optional Retrieval settings, for the first document.
+	EmbeddingModel         string            `json:"embedding_model,omitempty"`          // Optional Name of the embedding model, for the first document.
+	EmbeddingModelProvider string            `json:"embedding_model_provider,omitempty"` // Optional Provider of the embedding model, for the first document.
+}
+
+// CreateDocumentByFileRequest - Settings for creating a document from a file.
+type CreateDocumentByFileRequest struct {
+	OriginalDocumentID     string            `json:"original_document_id,omitempty"`     // Optional ID of a document to replace.
+	IndexingTechnique      IndexingTechnique `json:"indexing_technique,omitempty"`       // Indexing technique, required for the first document of a knowledge base.
+	DocForm                DocForm           `json:"doc_form,omitempty"`                 // Optional Chunk structure, `text_model` by default.
+	DocLanguage            string            `json:"doc_language,omitempty"`             // Optional Document language, for `qa_model`, such as `English`.
+	ProcessRule            ProcessRule       `json:"process_rule"`                       // Cleaning and segmentation.
+	RetrievalModel         *RetrievalModel   `json:"retrieval_model,omitempty"`          // Optional Retrieval settings, for the first document.
+	EmbeddingModel         string            `json:"embedding_model,omitempty"`          // Optional Name of the embedding model, for the first document.
+	EmbeddingModelProvider string            `json:"embedding_model_provider,omitempty"` // Optional Provider of the embedding model, for the first document.
+}
+
+// UpdateDocumentByTextRequest - Request body for updating a document with text, only the fields set are updated.
+type UpdateDocumentByTextRequest struct {
+	Name        string       `json:"name,omitempty"`         // Optional Document name.
+	Text        string       `json:"text,omitempty"`         // Optional Document content.
+	ProcessRule *ProcessRule `json:"process_rule,omitempty"` // Optional Cleaning and segmentation.
+}
+
+// UpdateDocumentByFileRequest - Settings for updating a document with a file, only the fields set are updated.
+type UpdateDocumentByFileRequest struct {
+	Name        string       `json:"name,omitempty"`         // Optional Document name.
+	ProcessRule *ProcessRule `json:"process_rule,omitempty"` // Optional Cleaning and segmentation.
+}
+
+// CreateDocumentByText - Creates a document from text in the knowledge base, the document is then indexed in the background.
+func (c *DatasetClient) CreateDocumentByText(ctx context.Context, datasetID string, req CreateDocumentByTextRequest) (*DocumentResponse, error) {
+	return c.sendDocument(ctx, fmt.Sprintf("%s/%s/document/create-by-text", datasetEndpoint, datasetID), req)
+}
+
+// CreateDocumentByFile - Creates a document in the knowledge base from the file read from r, streamed while it is sent.
+// The document is then indexed in the background.
+func (c *DatasetClient) CreateDocumentByFile(ctx context.Context, datasetID string, req CreateDocumentByFileRequest, filename string, r io.Reader) (*DocumentResponse, error) {
+	return c.uploadDocument(ctx, fmt.Sprintf("%s/%s/document/create-by-file", datasetEndpoint, datasetID), req, filename, r)
+}
+
+// UpdateDocumentByText - Updates a document of the knowledge base with text, the document is then indexed again.
+func (c *DatasetClient) UpdateDocumentByText(ctx context.Context, datasetID, documentID string, req UpdateDocumentByTextRequest) (*DocumentResponse, error) {
+	return c.sendDocument(ctx, fmt.Sprintf("%s/%s/documents/%s/update-by-text", datasetEndpoint, datasetID, documentID), req)
+}
+
+// UpdateDocumentByFile - Updates a document of the knowledge base with the file read from r, streamed while it is sent.
+// The document is then indexed again.
+func (c *DatasetClient) UpdateDocumentByFile(ctx context.Context, datasetID, documentID string, req UpdateDocumentByFileRequest, filename string, r io.Reader) (*DocumentResponse, error) {
+	return c.uploadDocument(ctx, fmt.Sprintf("%s/%s/documents/%s/update-by-file", datasetEndpoint, datasetID, documentID), req, filename, r)
+}
+
+// sendDocument - Sends a document creation or update request with a JSON body.
+func (c *DatasetClient) sendDocument(ctx context.Context, path string, req interface{}) (*DocumentResponse, error) {
+	request, err := c.client.newRequest(ctx, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DocumentResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// uploadDocument - Sends a document creation or update request with the settings in the `data` form field and the file.
+func (c *DatasetClient) uploadDocument(ctx context.Context, path string, req interface{}, filename string, r io.Reader) (*DocumentResponse, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	request, err := c.client.newMultipartRequest(ctx, path, map[string]string{"data": string(data)}, "file", filename, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DocumentResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListDocuments - Lists the documents of a knowledge base.
+func (c *DatasetClient) ListDocuments(ctx context.Context, datasetID string, req ListDocumentsRequest) (*DocumentsResponse, error) {
+	query := url.Values{}
+	if req.Keyword != "" {
+		query.Set("keyword", req.Keyword)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	request, err := c.client.newRequest(ctx, "GET", fmt.Sprintf("%s/%s/documents?%s", datasetEndpoint, datasetID, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DocumentsResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Documents - Returns a pager over the documents of a knowledge base from req.Page, fetching req.Limit documents per page.
+func (c *DatasetClient) Documents(ctx context.Context, datasetID string, req ListDocumentsRequest) *Pager[Document] {
+	return newPagePager(ctx, req.Page, func(ctx context.Context, page int) ([]Document, bool, error) {
+		req.Page = page
+		response, err := c.ListDocuments(ctx, datasetID, req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response.Data, response.HasMore, nil
+	})
+}
+
+// GetDocument - Gets the details of a document of a knowledge base.
+func (c *DatasetClient) GetDocument(ctx context.Context, datasetID, documentID string) (*Document, error) {
+	request, err := c.client.newRequest(ctx, "GET", fmt.Sprintf("%s/%s/documents/%s", datasetEndpoint, datasetID, documentID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response Document
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DeleteDocument - Deletes a document of a knowledge base.
+func (c *DatasetClient) DeleteDocument(ctx context.Context, datasetID, documentID string) error {
+	request, err := c.client.newRequest(ctx, "DELETE", fmt.Sprintf("%s/%s/documents/%s", datasetEndpoint, datasetID, documentID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.client.do(request, nil)
+}
+
+// UpdateDocumentStatus - Enables, disables, archives or unarchives documents of a knowledge base in one call.
+func (c *DatasetClient) UpdateDocumentStatus(ctx context.Context, datasetID string, action DocumentAction, documentIDs []string) error {
+	request, err := c.client.newRequest(ctx, "PATCH", fmt.Sprintf("%s/%s/documents/status/%s", datasetEndpoint, datasetID, action), documentStatusRequest{DocumentIDs: documentIDs})
+	if err != nil {
+		return err
+	}
+
+	return c.client.do(request, nil)
+}
+
+// GetDocumentUploadFile - Gets the file a document was created from, with its download URL.
+func (c *DatasetClient) GetDocumentUploadFile(ctx context.Context, datasetID, documentID string) (*DocumentUploadFile, error) {
+	request, err := c.client.newRequest(ctx, "GET", fmt.Sprintf("%s/%s/documents/%s/upload-file", datasetEndpoint, datasetID, documentID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DocumentUploadFile
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
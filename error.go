@@ -0,0 +1,93 @@
+package dify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Sentinel errors for the error codes documented by the Dify API, to be used with errors.Is.
+var (
+	ErrInvalidParam                   = &APIError{Code: "invalid_param"}                       // Abnormal parameter input.
+	ErrAppUnavailable                 = &APIError{Code: "app_unavailable"}                     // App configuration unavailable.
+	ErrNotChatApp                     = &APIError{Code: "not_chat_app"}                        // App mode does not match the chat API.
+	ErrNotCompletionApp               = &APIError{Code: "not_completion_app"}                  // App mode does not match the completion API.
+	ErrNotWorkflowApp                 = &APIError{Code: "not_workflow_app"}                    // App mode does not match the workflow API.
+	ErrConversationNotExists          = &APIError{Code: "conversation_not_exists"}             // Conversation does not exist.
+	ErrNotFound                       = &APIError{Code: "not_found"}                           // Requested resource does not exist.
+	ErrProviderNotInitialize          = &APIError{Code: "provider_not_initialize"}             // No available model credential configuration.
+	ErrProviderQuotaExceeded          = &APIError{Code: "provider_quota_exceeded"}             // Model invocation quota insufficient.
+	ErrModelCurrentlyNotSupport       = &APIError{Code: "model_currently_not_support"}         // Current model unavailable.
+	ErrCompletionRequestError         = &APIError{Code: "completion_request_error"}            // Text generation failed.
+	ErrWorkflowRequestError           = &APIError{Code: "workflow_request_error"}              // Workflow execution failed.
+	ErrNoFileUploaded                 = &APIError{Code: "no_file_uploaded"}                    // A file must be provided.
+	ErrTooManyFiles                   = &APIError{Code: "too_many_files"}                      // Currently only one file is accepted.
+	ErrUnsupportedPreview             = &APIError{Code: "unsupported_preview"}                 // The file does not support preview.
+	ErrUnsupportedEstimate            = &APIError{Code: "unsupported_estimate"}                // The file does not support estimation.
+	ErrFileTooLarge                   = &APIError{Code: "file_too_large"}                      // The file is too large.
+	ErrUnsupportedFileType            = &APIError{Code: "unsupported_file_type"}               // Unsupported file type.
+	ErrS3ConnectionFailed             = &APIError{Code: "s3_connection_failed"}                // Unable to connect to S3 service.
+	ErrS3PermissionDenied             = &APIError{Code: "s3_permission_denied"}                // No permission to upload files to S3.
+	ErrS3FileTooLarge                 = &APIError{Code: "s3_file_too_large"}                   // File exceeds S3 size limit.
+	ErrNoAudioUploaded                = &APIError{Code: "no_audio_uploaded"}                   // An audio file must be provided.
+	ErrAudioTooLarge                  = &APIError{Code: "audio_too_large"}                     // The audio file is too large.
+	ErrUnsupportedAudioType           = &APIError{Code: "unsupported_audio_type"}              // Unsupported audio type.
+	ErrProviderNotSupportSpeechToText = &APIError{Code: "provider_not_support_speech_to_text"} // Model provider does not support speech to text.
+	ErrDatasetNameDuplicate           = &APIError{Code: "dataset_name_duplicate"}              // The knowledge base name already exists.
+	ErrDatasetInUse                   = &APIError{Code: "dataset_in_use"}                      // The knowledge base is used by applications.
+	ErrDatasetNotInitialized          = &APIError{Code: "dataset_not_initialized"}             // The knowledge base has no indexing technique yet.
+	ErrArchivedDocumentImmutable      = &APIError{Code: "archived_document_immutable"}         // Archived documents cannot be edited.
+	ErrDocumentIndexing               = &APIError{Code: "document_indexing"}                   // The document is being indexed.
+	ErrInvalidAction                  = &APIError{Code: "invalid_action"}                      // The status change does not apply to the document.
+	ErrUnauthorized                   = &APIError{Code: "unauthorized"}                        // Missing or invalid API key.
+	ErrInternalServerError            = &APIError{Code: "internal_server_error"}               // Internal server error.
+)
+
+// APIError - Error response returned by the Dify API.
+type APIError struct {
+	StatusCode int         `json:"status"`           // HTTP status code.
+	Code       string      `json:"code"`             // Error code, such as `invalid_param`.
+	Message    string      `json:"message"`          // Error message.
+	Params     interface{} `json:"params,omitempty"` // Optional parameters related to the error.
+}
+
+// Error - Implements the error interface.
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("unexpected response status %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("unexpected response status %d (%s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Is - Reports whether the error matches target, comparing the code and status code when they are set on target.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	if t.Code != "" && t.Code != e.Code {
+		return false
+	}
+	if t.StatusCode != 0 && t.StatusCode != e.StatusCode {
+		return false
+	}
+	return true
+}
+
+// newAPIError - Builds an APIError from a non-successful response, falling back to the raw body for non-JSON payloads.
+func newAPIError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil || (apiErr.Code == "" && apiErr.Message == "") {
+		apiErr = &APIError{Message: string(bytes.TrimSpace(body))}
+	}
+	apiErr.StatusCode = resp.StatusCode
+
+	return apiErr
+}
@@ -0,0 +1,493 @@
+package dify
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Event - Event received from a streaming response, use a type switch on the concrete event types.
+type Event interface {
+	EventName() string   // SSE event name, such as `message` or `node_finished`.
+	EventTaskID() string // Task ID of the generation, empty for events without one.
+}
+
+// EventHeader - Fields shared by all streaming events.
+type EventHeader struct {
+	Event  string `json:"event"`             // SSE event name.
+	TaskID string `json:"task_id,omitempty"` // Task ID, used for request tracking and the stop response interface.
+}
+
+// EventName - Returns the SSE event name.
+func (h EventHeader) EventName() string {
+	return h.Event
+}
+
+// EventTaskID - Returns the task ID.
+func (h EventHeader) EventTaskID() string {
+	return h.TaskID
+}
+
+// AgentThought - Thought of an Agent iteration, including the tool call and its result.
+type AgentThought struct {
+	ID           string   `json:"id"`                      // Agent thought ID. Each Agent iteration will have a unique id.
+	MessageID    string   `json:"message_id,omitempty"`    // Message unique ID.
+	Position     int      `json:"position"`                // The position of agent_thought in the message, such as the first iteration position is 1.
+	Thought      string   `json:"thought"`                 // The agent's thoughts.
+	Observation  string   `json:"observation"`             // The result returned by the tool call.
+	Tool         string   `json:"tool"`                    // List of tools to use, separate multiple tools with `;`.
+	ToolInput    string   `json:"tool_input"`              // Tool input, a string in JSON format (object).
+	MessageFiles []string `json:"message_files,omitempty"` // Current agent_thought associated file ID, in streaming mode.
+	Files        []string `json:"files,omitempty"`         // Current agent_thought associated file ID, in the conversation history.
+	CreatedAt    int      `json:"created_at,omitempty"`    // Creation timestamp, such as: 1705395332.
+}
+
+// MessageFile - File attached to a message.
+type MessageFile struct {
+	ID        string `json:"id"`         // File unique ID.
+	Type      string `json:"type"`       // File type, such as image.
+	BelongsTo string `json:"belongs_to"` // File owner, user or assistant.
+	URL       string `json:"url"`        // File access address.
+}
+
+// MessageEvent - Text chunk of the answer, for the `message` and `agent_message` events.
+type MessageEvent struct {
+	EventHeader
+	ID             string `json:"id,omitempty"`         // Message unique ID.
+	MessageID      string `json:"message_id"`           // Message unique ID.
+	ConversationID string `json:"conversation_id"`      // Session ID.
+	Answer         string `json:"answer"`               // Block response content.
+	CreatedAt      int    `json:"created_at,omitempty"` // Creation timestamp, such as: 1705395332.
+}
+
+// AgentThoughtEvent - Thought of an Agent iteration, for the `agent_thought` event.
+type AgentThoughtEvent struct {
+	EventHeader
+	AgentThought
+	ConversationID string `json:"conversation_id,omitempty"` // Session ID.
+}
+
+// MessageFileEvent - File created by a tool, for the `message_file` event.
+type MessageFileEvent struct {
+	EventHeader
+	MessageFile
+	ConversationID string `json:"conversation_id,omitempty"` // Session ID.
+}
+
+// MessageEndEvent - End of the message, for the `message_end` event.
+type MessageEndEvent struct {
+	EventHeader
+	ID             string   `json:"id,omitempty"`    // Message unique ID.
+	MessageID      string   `json:"message_id"`      // Message unique ID.
+	ConversationID string   `json:"conversation_id"` // Session ID.
+	Metadata       Metadata `json:"metadata"`        // Message metadata.
+}
+
+// MessageReplaceEvent - Replacement of the whole answer by content moderation, for the `message_replace` event.
+type MessageReplaceEvent struct {
+	EventHeader
+	MessageID      string `json:"message_id"`           // Message unique ID.
+	ConversationID string `json:"conversation_id"`      // Session ID.
+	Answer         string `json:"answer"`               // Replacement content.
+	CreatedAt      int    `json:"created_at,omitempty"` // Creation timestamp, such as: 1705395332.
+}
+
+// TTSMessageEvent - Audio chunk of the speech synthesis, for the `tts_message` and `tts_message_end` events.
+type TTSMessageEvent struct {
+	EventHeader
+	MessageID string `json:"message_id"`           // Message unique ID.
+	Audio     string `json:"audio"`                // The audio block after speech synthesis is encoded with Base64 text content, empty at the end.
+	CreatedAt int    `json:"created_at,omitempty"` // Creation timestamp, such as: 1705395332.
+}
+
+// ErrorEvent - Error raised while streaming, for the `error` event.
+type ErrorEvent struct {
+	EventHeader
+	MessageID string `json:"message_id,omitempty"` // Message unique ID.
+	Status    int    `json:"status"`               // HTTP status code.
+	Code      string `json:"code"`                 // Error code.
+	Message   string `json:"message"`              // Error message.
+}
+
+// APIError - Returns the event as an *APIError, to be matched with errors.Is like the errors of blocking calls.
+func (e *ErrorEvent) APIError() *APIError {
+	return &APIError{StatusCode: e.Status, Code: e.Code, Message: e.Message}
+}
+
+// PingEvent - Keep-alive event, for the `ping` event.
+type PingEvent struct {
+	EventHeader
+}
+
+// WorkflowStartedData - Details of the `workflow_started` event.
+type WorkflowStartedData struct {
+	ID             string                 `json:"id"`               // Workflow execution ID.
+	WorkflowID     string                 `json:"workflow_id"`      // Associated Workflow ID, the version that runs.
+	SequenceNumber int                    `json:"sequence_number"`  // Self-incrementing sequence number, self-incrementing within the App, starting from 1.
+	Inputs         map[string]interface{} `json:"inputs,omitempty"` // Inputs of the workflow.
+	CreatedAt      int                    `json:"created_at"`       // Start time.
+}
+
+// WorkflowStartedEvent - Start of the workflow execution, for the `workflow_started` event.
+type WorkflowStartedEvent struct {
+	EventHeader
+	WorkflowRunID string              `json:"workflow_run_id"` // Workflow execution ID.
+	Data          WorkflowStartedData `json:"data"`            // Details.
+}
+
+// NodeScope - Parallel branch, iteration and loop running a node execution, empty at the top level.
+type NodeScope struct {
+	ParallelID                string `json:"parallel_id,omitempty"`                   // ID of the parallel branch group running the node.
+	ParallelStartNodeID       string `json:"parallel_start_node_id,omitempty"`        // Node ID starting the parallel branch.
+	ParentParallelID          string `json:"parent_parallel_id,omitempty"`            // ID of the parallel branch group containing the parallel.
+	ParentParallelStartNodeID string `json:"parent_parallel_start_node_id,omitempty"` // Node ID starting the parent parallel branch.
+	IterationID               string `json:"iteration_id,omitempty"`                  // Node ID of the iteration containing the node.
+	LoopID                    string `json:"loop_id,omitempty"`                       // Node ID of the loop containing the node.
+}
+
+// NodeStartedData - Details of the `node_started` event.
+type NodeStartedData struct {
+	NodeScope
+	ID                string                 `json:"id"`                            // Node execution ID.
+	NodeID            string                 `json:"node_id"`                       // Node ID.
+	NodeType          string                 `json:"node_type"`                     // Node type, such as: llm, code, tool, etc.
+	Title             string                 `json:"title"`                         // Node name.
+	Index             int                    `json:"index"`                         // Execution sequence number, used to display the Tracing Node sequence.
+	PredecessorNodeID string                 `json:"predecessor_node_id,omitempty"` // Prefix node ID, used to display the execution path on the canvas.
+	Inputs            map[string]interface{} `json:"inputs,omitempty"`              // All the previous node variables used in the node.
+	CreatedAt         int                    `json:"created_at"`                    // Start time.
+}
+
+// NodeStartedEvent - Start of a node execution, for the `node_started` event.
+type NodeStartedEvent struct {
+	EventHeader
+	WorkflowRunID string          `json:"workflow_run_id"` // Workflow execution ID.
+	Data          NodeStartedData `json:"data"`            // Details.
+}
+
+// NodeExecutionMetadata - Metadata of a node execution.
+type NodeExecutionMetadata struct {
+	TotalTokens int         `json:"total_tokens,omitempty"` // Optional Total tokens used.
+	TotalPrice  json.Number `json:"total_price,omitempty"`  // Optional Total cost.
+	Currency    string      `json:"currency,omitempty"`     // Currency, such as USD/RMB.
+
+	IterationID         string `json:"iteration_id,omitempty"`           // Node ID of the iteration containing the node.
+	IterationIndex      int    `json:"iteration_index,omitempty"`        // Index of the iteration round, starting from 0.
+	LoopID              string `json:"loop_id,omitempty"`                // Node ID of the loop containing the node.
+	LoopIndex           int    `json:"loop_index,omitempty"`             // Index of the loop round, starting from 0.
+	ParallelID          string `json:"parallel_id,omitempty"`            // ID of the parallel branch group running the node.
+	ParallelStartNodeID string `json:"parallel_start_node_id,omitempty"` // Node ID starting the parallel branch.
+	ParentParallelID    string `json:"parent_parallel_id,omitempty"`     // ID of the parallel branch group containing the parallel.
+}
+
+// NodeFinishedData - Details of the `node_finished` and `node_retry` events.
+type NodeFinishedData struct {
+	NodeScope
+	ID                string                 `json:"id"`                            // Node execution ID.
+	NodeID            string                 `json:"node_id"`                       // Node ID.
+	NodeType          string                 `json:"node_type"`                     // Node type, such as: llm, code, tool, etc.
+	Title             string                 `json:"title"`                         // Node name.
+	Index             int                    `json:"index"`                         // Execution sequence number, used to display the Tracing Node sequence.
+	PredecessorNodeID string                 `json:"predecessor_node_id,omitempty"` // Prefix node ID, used to display the execution path on the canvas.
+	Inputs            map[string]interface{} `json:"inputs,omitempty"`              // All the previous node variables used in the node.
+	ProcessData       map[string]interface{} `json:"process_data,omitempty"`        // Optional Node process data.
+	Outputs           map[string]interface{} `json:"outputs,omitempty"`             // Optional Output content.
+	Status            string                 `json:"status"`                        // Execution status running/succeeded/failed/stopped.
+	Error             string                 `json:"error,omitempty"`               // Optional The reason for the error.
+	ElapsedTime       float64                `json:"elapsed_time,omitempty"`        // Optional time consumed (s).
+	ExecutionMetadata NodeExecutionMetadata  `json:"execution_metadata"`            // Metadata.
+	CreatedAt         int                    `json:"created_at"`                    // Start time.
+	FinishedAt        int                    `json:"finished_at,omitempty"`         // End time.
+	RetryIndex        int                    `json:"retry_index,omitempty"`         // Index of the failed attempt, for the `node_retry` event.
+}
+
+// NodeFinishedEvent - End of a node execution, for the `node_finished` event.
+type NodeFinishedEvent struct {
+	EventHeader
+	WorkflowRunID string           `json:"workflow_run_id"` // Workflow execution ID.
+	Data          NodeFinishedData `json:"data"`            // Details.
+}
+
+// WorkflowFinishedEvent - End of the workflow execution, for the `workflow_finished` event.
+type WorkflowFinishedEvent struct {
+	EventHeader
+	WorkflowRunID string         `json:"workflow_run_id"` // Workflow execution ID.
+	Data          WorkflowResult `json:"data"`            // Details.
+}
+
+// TextChunkEvent - Text chunk of a workflow output, for the `text_chunk` event.
+type TextChunkEvent struct {
+	EventHeader
+	WorkflowRunID string `json:"workflow_run_id"` // Workflow execution ID.
+	Data          struct {
+		Text                 string   `json:"text"`                   // Text content.
+		FromVariableSelector []string `json:"from_variable_selector"` // Path of the output variable the text comes from.
+	} `json:"data"` // Details.
+}
+
+// NodeRetryEvent - Failed attempt of a node execution that is retried, for the `node_retry` event.
+type NodeRetryEvent struct {
+	EventHeader
+	WorkflowRunID string           `json:"workflow_run_id"` // Workflow execution ID.
+	Data          NodeFinishedData `json:"data"`            // Details of the failed attempt.
+}
+
+// IterationStartedData - Details of the `iteration_started` event.
+type IterationStartedData struct {
+	ID                  string                 `json:"id"`                               // Iteration execution ID.
+	NodeID              string                 `json:"node_id"`                          // Node ID of the iteration.
+	NodeType            string                 `json:"node_type"`                        // Node type, fixed to iteration.
+	Title               string                 `json:"title"`                            // Node name.
+	Inputs              map[string]interface{} `json:"inputs,omitempty"`                 // Inputs of the iteration.
+	Metadata            map[string]interface{} `json:"metadata,omitempty"`               // Metadata, such as the number of iterations.
+	CreatedAt           int                    `json:"created_at"`                       // Start time.
+	ParallelID          string                 `json:"parallel_id,omitempty"`            // ID of the parallel branch group running the iteration.
+	ParallelStartNodeID string                 `json:"parallel_start_node_id,omitempty"` // Node ID starting the parallel branch.
+}
+
+// IterationStartedEvent - Start of an iteration node, for the `iteration_started` event.
+type IterationStartedEvent struct {
+	EventHeader
+	WorkflowRunID string               `json:"workflow_run_id"` // Workflow execution ID.
+	Data          IterationStartedData `json:"data"`            // Details.
+}
+
+// IterationNextData - Details of the `iteration_next` event.
+type IterationNextData struct {
+	ID                  string      `json:"id"`                               // Iteration execution ID.
+	NodeID              string      `json:"node_id"`                          // Node ID of the iteration.
+	NodeType            string      `json:"node_type"`                        // Node type, fixed to iteration.
+	Title               string      `json:"title"`                            // Node name.
+	Index               int         `json:"index"`                            // Index of the round starting, from 0.
+	PreIterationOutput  interface{} `json:"pre_iteration_output,omitempty"`   // Output of the previous round.
+	CreatedAt           int         `json:"created_at"`                       // Start time of the round.
+	ParallelID          string      `json:"parallel_id,omitempty"`            // ID of the parallel branch group running the iteration.
+	ParallelStartNodeID string      `json:"parallel_start_node_id,omitempty"` // Node ID starting the parallel branch.
+	ParallelModeRunID   string      `json:"parallel_mode_run_id,omitempty"`   // Run ID of the round, for iterations in parallel mode.
+	Duration            float64     `json:"duration,omitempty"`               // Duration of the previous round (s).
+}
+
+// IterationNextEvent - Start of the next round of an iteration, for the `iteration_next` event.
+type IterationNextEvent struct {
+	EventHeader
+	WorkflowRunID string            `json:"workflow_run_id"` // Workflow execution ID.
+	Data          IterationNextData `json:"data"`            // Details.
+}
+
+// IterationCompletedData - Details of the `iteration_completed` event.
+type IterationCompletedData struct {
+	ID                  string                 `json:"id"`                               // Iteration execution ID.
+	NodeID              string                 `json:"node_id"`                          // Node ID of the iteration.
+	NodeType            string                 `json:"node_type"`                        // Node type, fixed to iteration.
+	Title               string                 `json:"title"`                            // Node name.
+	Inputs              map[string]interface{} `json:"inputs,omitempty"`                 // Inputs of the iteration.
+	Outputs             map[string]interface{} `json:"outputs,omitempty"`                // Outputs of the iteration.
+	Status              string                 `json:"status"`                           // Execution status succeeded/failed/stopped.
+	Error               string                 `json:"error,omitempty"`                  // Optional The reason for the error.
+	ElapsedTime         float64                `json:"elapsed_time,omitempty"`           // Time consumed (s).
+	TotalTokens         int                    `json:"total_tokens,omitempty"`           // Total tokens used by all rounds.
+	ExecutionMetadata   NodeExecutionMetadata  `json:"execution_metadata"`               // Metadata.
+	Steps               int                    `json:"steps"`                            // Number of rounds.
+	CreatedAt           int                    `json:"created_at"`                       // Start time.
+	FinishedAt          int                    `json:"finished_at,omitempty"`            // End time.
+	ParallelID          string                 `json:"parallel_id,omitempty"`            // ID of the parallel branch group running the iteration.
+	ParallelStartNodeID string                 `json:"parallel_start_node_id,omitempty"` // Node ID starting the parallel branch.
+}
+
+// IterationCompletedEvent - End of an iteration node, for the `iteration_completed` event.
+type IterationCompletedEvent struct {
+	EventHeader
+	WorkflowRunID string                 `json:"workflow_run_id"` // Workflow execution ID.
+	Data          IterationCompletedData `json:"data"`            // Details.
+}
+
+// LoopStartedData - Details of the `loop_started` event.
+type LoopStartedData struct {
+	ID                  string                 `json:"id"`                               // Loop execution ID.
+	NodeID              string                 `json:"node_id"`                          // Node ID of the loop.
+	NodeType            string                 `json:"node_type"`                        // Node type, fixed to loop.
+	Title               string                 `json:"title"`                            // Node name.
+	Inputs              map[string]interface{} `json:"inputs,omitempty"`                 // Inputs of the loop.
+	Metadata            map[string]interface{} `json:"metadata,omitempty"`               // Metadata, such as the loop count.
+	CreatedAt           int                    `json:"created_at"`                       // Start time.
+	ParallelID          string                 `json:"parallel_id,omitempty"`            // ID of the parallel branch group running the loop.
+	ParallelStartNodeID string                 `json:"parallel_start_node_id,omitempty"` // Node ID starting the parallel branch.
+}
+
+// LoopStartedEvent - Start of a loop node, for the `loop_started` event.
+type LoopStartedEvent struct {
+	EventHeader
+	WorkflowRunID string          `json:"workflow_run_id"` // Workflow execution ID.
+	Data          LoopStartedData `json:"data"`            // Details.
+}
+
+// LoopNextData - Details of the `loop_next` event.
+type LoopNextData struct {
+	ID                  string      `json:"id"`                               // Loop execution ID.
+	NodeID              string      `json:"node_id"`                          // Node ID of the loop.
+	NodeType            string      `json:"node_type"`                        // Node type, fixed to loop.
+	Title               string      `json:"title"`                            // Node name.
+	Index               int         `json:"index"`                            // Index of the round starting, from 0.
+	PreLoopOutput       interface{} `json:"pre_loop_output,omitempty"`        // Output of the previous round.
+	CreatedAt           int         `json:"created_at"`                       // Start time of the round.
+	ParallelID          string      `json:"parallel_id,omitempty"`            // ID of the parallel branch group running the loop.
+	ParallelStartNodeID string      `json:"parallel_start_node_id,omitempty"` // Node ID starting the parallel branch.
+	ParallelModeRunID   string      `json:"parallel_mode_run_id,omitempty"`   // Run ID of the round.
+	Duration            float64     `json:"duration,omitempty"`               // Duration of the previous round (s).
+}
+
+// LoopNextEvent - Start of the next round of a loop, for the `loop_next` event.
+type LoopNextEvent struct {
+	EventHeader
+	WorkflowRunID string       `json:"workflow_run_id"` // Workflow execution ID.
+	Data          LoopNextData `json:"data"`            // Details.
+}
+
+// LoopCompletedData - Details of the `loop_completed` event.
+type LoopCompletedData struct {
+	ID                  string                 `json:"id"`                               // Loop execution ID.
+	NodeID              string                 `json:"node_id"`                          // Node ID of the loop.
+	NodeType            string                 `json:"node_type"`                        // Node type, fixed to loop.
+	Title               string                 `json:"title"`                            // Node name.
+	Inputs              map[string]interface{} `json:"inputs,omitempty"`                 // Inputs of the loop.
+	Outputs             map[string]interface{} `json:"outputs,omitempty"`                // Outputs of the loop.
+	Status              string                 `json:"status"`                           // Execution status succeeded/failed/stopped.
+	Error               string                 `json:"error,omitempty"`                  // Optional The reason for the error.
+	ElapsedTime         float64                `json:"elapsed_time,omitempty"`           // Time consumed (s).
+	TotalTokens         int                    `json:"total_tokens,omitempty"`           // Total tokens used by all rounds.
+	ExecutionMetadata   NodeExecutionMetadata  `json:"execution_metadata"`               // Metadata.
+	Steps               int                    `json:"steps"`                            // Number of rounds.
+	CreatedAt           int                    `json:"created_at"`                       // Start time.
+	FinishedAt          int                    `json:"finished_at,omitempty"`            // End time.
+	ParallelID          string                 `json:"parallel_id,omitempty"`            // ID of the parallel branch group running the loop.
+	ParallelStartNodeID string                 `json:"parallel_start_node_id,omitempty"` // Node ID starting the parallel branch.
+}
+
+// LoopCompletedEvent - End of a loop node, for the `loop_completed` event.
+type LoopCompletedEvent struct {
+	EventHeader
+	WorkflowRunID string            `json:"workflow_run_id"` // Workflow execution ID.
+	Data          LoopCompletedData `json:"data"`            // Details.
+}
+
+// ParallelBranchData - Details of the `parallel_branch_started` and `parallel_branch_finished` events.
+type ParallelBranchData struct {
+	ParallelID                string `json:"parallel_id"`                             // ID of the parallel branch group.
+	ParallelBranchID          string `json:"parallel_branch_id"`                      // Node ID starting the branch.
+	ParentParallelID          string `json:"parent_parallel_id,omitempty"`            // ID of the parallel branch group containing the parallel.
+	ParentParallelStartNodeID string `json:"parent_parallel_start_node_id,omitempty"` // Node ID starting the parent parallel branch.
+	IterationID               string `json:"iteration_id,omitempty"`                  // Node ID of the iteration containing the parallel.
+	LoopID                    string `json:"loop_id,omitempty"`                       // Node ID of the loop containing the parallel.
+	Status                    string `json:"status,omitempty"`                        // Execution status succeeded/failed, when finished.
+	Error                     string `json:"error,omitempty"`                         // Optional The reason for the error, when finished.
+	CreatedAt                 int    `json:"created_at"`                              // Creation timestamp.
+}
+
+// ParallelBranchStartedEvent - Start of a parallel branch, for the `parallel_branch_started` event.
+type ParallelBranchStartedEvent struct {
+	EventHeader
+	WorkflowRunID string             `json:"workflow_run_id"` // Workflow execution ID.
+	Data          ParallelBranchData `json:"data"`            // Details.
+}
+
+// ParallelBranchFinishedEvent - End of a parallel branch, for the `parallel_branch_finished` event.
+type ParallelBranchFinishedEvent struct {
+	EventHeader
+	WorkflowRunID string             `json:"workflow_run_id"` // Workflow execution ID.
+	Data          ParallelBranchData `json:"data"`            // Details.
+}
+
+// AgentLogData - Details of the `agent_log` event.
+type AgentLogData struct {
+	NodeExecutionID string                 `json:"node_execution_id"`   // Execution ID of the agent node.
+	ID              string                 `json:"id"`                  // Log ID.
+	Label           string                 `json:"label"`               // Log label, such as the round or the tool called.
+	ParentID        string                 `json:"parent_id,omitempty"` // ID of the parent log.
+	Error           string                 `json:"error,omitempty"`     // Optional The reason for the error.
+	Status          string                 `json:"status"`              // Log status, start/success/error.
+	Data            map[string]interface{} `json:"data,omitempty"`      // Log content.
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`  // Log metadata, such as elapsed time and tokens.
+	NodeID          string                 `json:"node_id"`             // Node ID of the agent node.
+}
+
+// AgentLogEvent - Step of an agent node strategy, for the `agent_log` event.
+type AgentLogEvent struct {
+	EventHeader
+	WorkflowRunID string       `json:"workflow_run_id,omitempty"` // Workflow execution ID.
+	Data          AgentLogData `json:"data"`                      // Details.
+}
+
+// UnknownEvent - Event not modeled by this package, kept with its raw payload.
+type UnknownEvent struct {
+	EventHeader
+	Raw json.RawMessage `json:"-"` // Raw event data.
+}
+
+// decodeEvent - Decodes the SSE event into the concrete Event type matching its name.
+func decodeEvent(e sseEvent) (Event, error) {
+	if e.Event == "ping" {
+		return &PingEvent{EventHeader{Event: e.Event}}, nil
+	}
+
+	var header EventHeader
+	if err := json.Unmarshal(e.Data, &header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	var event Event
+	switch header.Event {
+	case "message", "agent_message":
+		event = &MessageEvent{}
+	case "agent_thought":
+		event = &AgentThoughtEvent{}
+	case "message_file":
+		event = &MessageFileEvent{}
+	case "message_end":
+		event = &MessageEndEvent{}
+	case "message_replace":
+		event = &MessageReplaceEvent{}
+	case "tts_message", "tts_message_end":
+		event = &TTSMessageEvent{}
+	case "error":
+		event = &ErrorEvent{}
+	case "ping":
+		event = &PingEvent{}
+	case "workflow_started":
+		event = &WorkflowStartedEvent{}
+	case "node_started":
+		event = &NodeStartedEvent{}
+	case "node_finished":
+		event = &NodeFinishedEvent{}
+	case "workflow_finished":
+		event = &WorkflowFinishedEvent{}
+	case "text_chunk":
+		event = &TextChunkEvent{}
+	case "node_retry":
+		event = &NodeRetryEvent{}
+	case "iteration_started":
+		event = &IterationStartedEvent{}
+	case "iteration_next":
+		event = &IterationNextEvent{}
+	case "iteration_completed":
+		event = &IterationCompletedEvent{}
+	case "loop_started":
+		event = &LoopStartedEvent{}
+	case "loop_next":
+		event = &LoopNextEvent{}
+	case "loop_completed":
+		event = &LoopCompletedEvent{}
+	case "parallel_branch_started":
+		event = &ParallelBranchStartedEvent{}
+	case "parallel_branch_finished":
+		event = &ParallelBranchFinishedEvent{}
+	case "agent_log":
+		event = &AgentLogEvent{}
+	default:
+		return &UnknownEvent{EventHeader: header, Raw: json.RawMessage(e.Data)}, nil
+	}
+
+	if err := json.Unmarshal(e.Data, event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s event: %w", header.Event, err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,145 @@
+package dify
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	scope := NodeScope{
+		ParallelID:                "p1",
+		ParallelStartNodeID:       "a",
+		ParentParallelID:          "p0",
+		ParentParallelStartNodeID: "x",
+		IterationID:               "iter",
+		LoopID:                    "loop",
+	}
+	scopeJSON := `"parallel_id":"p1","parallel_start_node_id":"a","parent_parallel_id":"p0","parent_parallel_start_node_id":"x","iteration_id":"iter","loop_id":"loop"`
+
+	tests := []struct {
+		name string
+		data string
+		want Event
+	}{
+		{
+			name: "node_started",
+			data: `{"event":"node_started","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"n-1","node_id":"llm","node_type":"llm",` + scopeJSON + `}}`,
+			want: &NodeStartedEvent{
+				EventHeader:   EventHeader{Event: "node_started", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          NodeStartedData{NodeScope: scope, ID: "n-1", NodeID: "llm", NodeType: "llm"},
+			},
+		},
+		{
+			name: "node_finished",
+			data: `{"event":"node_finished","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"n-1","node_id":"llm","node_type":"llm","status":"succeeded",` + scopeJSON + `}}`,
+			want: &NodeFinishedEvent{
+				EventHeader:   EventHeader{Event: "node_finished", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          NodeFinishedData{NodeScope: scope, ID: "n-1", NodeID: "llm", NodeType: "llm", Status: "succeeded"},
+			},
+		},
+		{
+			name: "node_retry",
+			data: `{"event":"node_retry","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"n-1","node_id":"llm","status":"retry","error":"timeout","retry_index":1,"iteration_id":"iter"}}`,
+			want: &NodeRetryEvent{
+				EventHeader:   EventHeader{Event: "node_retry", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          NodeFinishedData{NodeScope: NodeScope{IterationID: "iter"}, ID: "n-1", NodeID: "llm", Status: "retry", Error: "timeout", RetryIndex: 1},
+			},
+		},
+		{
+			name: "iteration_started",
+			data: `{"event":"iteration_started","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","title":"Iterate","created_at":1705395332}}`,
+			want: &IterationStartedEvent{
+				EventHeader:   EventHeader{Event: "iteration_started", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          IterationStartedData{ID: "iter-1", NodeID: "iter", NodeType: "iteration", Title: "Iterate", CreatedAt: 1705395332},
+			},
+		},
+		{
+			name: "iteration_next",
+			data: `{"event":"iteration_next","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","index":1,"pre_iteration_output":"a"}}`,
+			want: &IterationNextEvent{
+				EventHeader:   EventHeader{Event: "iteration_next", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          IterationNextData{ID: "iter-1", NodeID: "iter", NodeType: "iteration", Index: 1, PreIterationOutput: "a"},
+			},
+		},
+		{
+			name: "iteration_completed",
+			data: `{"event":"iteration_completed","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","status":"succeeded","steps":2,"total_tokens":30}}`,
+			want: &IterationCompletedEvent{
+				EventHeader:   EventHeader{Event: "iteration_completed", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          IterationCompletedData{ID: "iter-1", NodeID: "iter", NodeType: "iteration", Status: "succeeded", Steps: 2, TotalTokens: 30},
+			},
+		},
+		{
+			name: "loop_started",
+			data: `{"event":"loop_started","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"loop-1","node_id":"loop","node_type":"loop","parallel_id":"p1"}}`,
+			want: &LoopStartedEvent{
+				EventHeader:   EventHeader{Event: "loop_started", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          LoopStartedData{ID: "loop-1", NodeID: "loop", NodeType: "loop", ParallelID: "p1"},
+			},
+		},
+		{
+			name: "loop_next",
+			data: `{"event":"loop_next","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"loop-1","node_id":"loop","node_type":"loop","index":2}}`,
+			want: &LoopNextEvent{
+				EventHeader:   EventHeader{Event: "loop_next", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          LoopNextData{ID: "loop-1", NodeID: "loop", NodeType: "loop", Index: 2},
+			},
+		},
+		{
+			name: "loop_completed",
+			data: `{"event":"loop_completed","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"loop-1","node_id":"loop","node_type":"loop","status":"failed","error":"boom","steps":3}}`,
+			want: &LoopCompletedEvent{
+				EventHeader:   EventHeader{Event: "loop_completed", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          LoopCompletedData{ID: "loop-1", NodeID: "loop", NodeType: "loop", Status: "failed", Error: "boom", Steps: 3},
+			},
+		},
+		{
+			name: "parallel_branch_started",
+			data: `{"event":"parallel_branch_started","task_id":"task-1","workflow_run_id":"run-1","data":{"parallel_id":"p1","parallel_branch_id":"a","iteration_id":"iter"}}`,
+			want: &ParallelBranchStartedEvent{
+				EventHeader:   EventHeader{Event: "parallel_branch_started", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          ParallelBranchData{ParallelID: "p1", ParallelBranchID: "a", IterationID: "iter"},
+			},
+		},
+		{
+			name: "parallel_branch_finished",
+			data: `{"event":"parallel_branch_finished","task_id":"task-1","workflow_run_id":"run-1","data":{"parallel_id":"p1","parallel_branch_id":"a","status":"failed","error":"boom"}}`,
+			want: &ParallelBranchFinishedEvent{
+				EventHeader:   EventHeader{Event: "parallel_branch_finished", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          ParallelBranchData{ParallelID: "p1", ParallelBranchID: "a", Status: "failed", Error: "boom"},
+			},
+		},
+		{
+			name: "agent_log",
+			data: `{"event":"agent_log","task_id":"task-1","workflow_run_id":"run-1","data":{"node_execution_id":"n-1","id":"log-1","label":"ROUND 1","status":"success","node_id":"agent"}}`,
+			want: &AgentLogEvent{
+				EventHeader:   EventHeader{Event: "agent_log", TaskID: "task-1"},
+				WorkflowRunID: "run-1",
+				Data:          AgentLogData{NodeExecutionID: "n-1", ID: "log-1", Label: "ROUND 1", Status: "success", NodeID: "agent"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeEvent(sseEvent{Data: []byte(tt.data)})
+			if err != nil {
+				t.Fatalf("decodeEvent() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeEvent() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
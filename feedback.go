@@ -0,0 +1,96 @@
+package dify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// appFeedbackEndpoint - Endpoint for the feedbacks of the application.
+const appFeedbackEndpoint = "/v1/app/feedbacks"
+
+// messageFeedbackRequest - Request body for submitting a message feedback.
+type messageFeedbackRequest struct {
+	Rating  Rating `json:"rating"`            // Rating, `like`, `dislike` or `null`.
+	User    string `json:"user"`              // Identity of the end user.
+	Content string `json:"content,omitempty"` // Specific content of the feedback.
+}
+
+// Feedback - Feedback given to a message of the application.
+type Feedback struct {
+	ID             string `json:"id"`               // Feedback ID.
+	AppID          string `json:"app_id"`           // Application ID.
+	ConversationID string `json:"conversation_id"`  // Conversation ID.
+	MessageID      string `json:"message_id"`       // Message ID.
+	Rating         Rating `json:"rating"`           // Rating, `like` or `dislike`.
+	Content        string `json:"content"`          // Specific content of the feedback.
+	FromSource     string `json:"from_source"`      // Source of the feedback, such as `user`.
+	FromEndUserID  string `json:"from_end_user_id"` // End user who gave the feedback.
+	FromAccountID  string `json:"from_account_id"`  // Account who gave the feedback.
+	CreatedAt      string `json:"created_at"`       // Creation time, such as: 2025-04-24T09:24:38.
+	UpdatedAt      string `json:"updated_at"`       // Update time, such as: 2025-04-24T09:24:38.
+}
+
+// FeedbacksResponse - Response body from the ListAppFeedbacks endpoint.
+type FeedbacksResponse struct {
+	Data []Feedback `json:"data"` // List of feedbacks.
+}
+
+// suggestedQuestionsResponse - Response body from the GetSuggestedQuestions endpoint.
+type suggestedQuestionsResponse struct {
+	Result string   `json:"result"` // Fixed to success.
+	Data   []string `json:"data"`   // Suggested questions.
+}
+
+// SubmitMessageFeedback - Rates a message, RatingNull revokes a previous rating.
+func (c *Client) SubmitMessageFeedback(ctx context.Context, messageID string, rating Rating, user, content string) error {
+	body := messageFeedbackRequest{Rating: rating, User: user, Content: content}
+	request, err := c.newRequest(ctx, "POST", fmt.Sprintf("%s/%s/feedbacks", messageEndpoint, messageID), body)
+	if err != nil {
+		return err
+	}
+
+	return c.do(request, nil)
+}
+
+// ListAppFeedbacks - Lists the feedbacks given to the messages of the application, page starts from 1.
+func (c *Client) ListAppFeedbacks(ctx context.Context, page, limit int) (*FeedbacksResponse, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	request, err := c.newRequest(ctx, "GET", appFeedbackEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response FeedbacksResponse
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetSuggestedQuestions - Gets the next questions suggested for a message.
+func (c *Client) GetSuggestedQuestions(ctx context.Context, messageID, user string) ([]string, error) {
+	query := url.Values{}
+	query.Set("user", user)
+
+	request, err := c.newRequest(ctx, "GET", fmt.Sprintf("%s/%s/suggested?%s", messageEndpoint, messageID, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response suggestedQuestionsResponse
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
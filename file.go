@@ -1,9 +1,168 @@
 package dify
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// fileUploadEndpoint - Endpoint for uploading a file.
+const fileUploadEndpoint = "/v1/files/upload"
+
+const (
+	FileTypeImage    FileType = "image"    // JPG, JPEG, PNG, GIF, WEBP, SVG.
+	FileTypeDocument FileType = "document" // TXT, MD, MARKDOWN, PDF, HTML, XLSX, XLS, DOCX, CSV, EML, MSG, PPTX, PPT, XML, EPUB.
+	FileTypeAudio    FileType = "audio"    // MP3, M4A, WAV, WEBM, AMR.
+	FileTypeVideo    FileType = "video"    // MP4, MOV, MPEG, MPGA.
+	FileTypeCustom   FileType = "custom"   // Other file types.
+)
+
+// FileType - File type, `image`, `document`, `audio`, `video` or `custom`.
+type FileType string
+
+const (
+	TransferMethodRemoteURL TransferMethod = "remote_url" // File referenced by its URL.
+	TransferMethodLocalFile TransferMethod = "local_file" // File uploaded with UploadFile.
+)
+
+// TransferMethod - Delivery method of a file, `remote_url` or `local_file`.
+type TransferMethod string
+
+// ErrInvalidFile - Returned, wrapped with the reason, when a file of a request is invalid.
+var ErrInvalidFile = errors.New("invalid file")
+
+// fileExtensions - File extensions of the file types, other extensions are `custom` files.
+var fileExtensions = map[FileType][]string{
+	FileTypeImage:    {"jpg", "jpeg", "png", "gif", "webp", "svg"},
+	FileTypeDocument: {"txt", "md", "markdown", "pdf", "html", "xlsx", "xls", "docx", "csv", "eml", "msg", "pptx", "ppt", "xml", "epub"},
+	FileTypeAudio:    {"mp3", "m4a", "wav", "webm", "amr"},
+	FileTypeVideo:    {"mp4", "mov", "mpeg", "mpga"},
+}
+
 // File - Uploaded File
 type File struct {
-	Type           string `json:"type"`                     // Supported types, `image` only.
-	TransferMethod string `json:"transfer_method"`          // Delivery method, `remote_url` or `local_file`.
-	Url            string `json:"url,omitempty"`            // Image URL.
-	UploadFileID   string `json:"upload_file_id,omitempty"` // Upload file ID.
+	Type           FileType       `json:"type"`                     // File type, `image`, `document`, `audio`, `video` or `custom`.
+	TransferMethod TransferMethod `json:"transfer_method"`          // Delivery method, `remote_url` or `local_file`.
+	Url            string         `json:"url,omitempty"`            // File URL, for `remote_url` only.
+	UploadFileID   string         `json:"upload_file_id,omitempty"` // Upload file ID, for `local_file` only.
+}
+
+// Validate - Checks the file type and that the URL or upload file ID matches the transfer method.
+func (f File) Validate() error {
+	switch f.Type {
+	case FileTypeImage, FileTypeDocument, FileTypeAudio, FileTypeVideo, FileTypeCustom:
+	default:
+		return fmt.Errorf("%w: unsupported type %q", ErrInvalidFile, f.Type)
+	}
+
+	switch f.TransferMethod {
+	case TransferMethodRemoteURL:
+		if f.Url == "" || f.UploadFileID != "" {
+			return fmt.Errorf("%w: remote_url requires url and no upload_file_id", ErrInvalidFile)
+		}
+	case TransferMethodLocalFile:
+		if f.UploadFileID == "" || f.Url != "" {
+			return fmt.Errorf("%w: local_file requires upload_file_id and no url", ErrInvalidFile)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported transfer method %q", ErrInvalidFile, f.TransferMethod)
+	}
+
+	return nil
+}
+
+// FileTypeOf - Returns the file type of a file extension, with or without the leading dot.
+func FileTypeOf(extension string) FileType {
+	extension = strings.ToLower(strings.TrimPrefix(extension, "."))
+	for fileType, extensions := range fileExtensions {
+		for _, e := range extensions {
+			if e == extension {
+				return fileType
+			}
+		}
+	}
+	return FileTypeCustom
+}
+
+// validateFiles - Validates the files of a request, and the File values or lists passed as input variables.
+func validateFiles(files []File, inputs map[string]interface{}) error {
+	for i, file := range files {
+		if err := file.Validate(); err != nil {
+			return fmt.Errorf("files[%d]: %w", i, err)
+		}
+	}
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var list []File
+		switch value := inputs[name].(type) {
+		case File:
+			list = []File{value}
+		case *File:
+			if value != nil {
+				list = []File{*value}
+			}
+		case []File:
+			list = value
+		case []*File:
+			for _, file := range value {
+				if file != nil {
+					list = append(list, *file)
+				}
+			}
+		}
+
+		for i, file := range list {
+			if err := file.Validate(); err != nil {
+				return fmt.Errorf("inputs[%s][%d]: %w", name, i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// UploadedFile - Response body from the UploadFile endpoint.
+type UploadedFile struct {
+	ID        string `json:"id"`         // Upload file ID.
+	Name      string `json:"name"`       // File name.
+	Size      int    `json:"size"`       // File size (byte).
+	Extension string `json:"extension"`  // File extension.
+	MimeType  string `json:"mime_type"`  // File mime-type.
+	CreatedBy string `json:"created_by"` // End user ID.
+	CreatedAt int    `json:"created_at"` // Creation timestamp, such as: 1705395332.
+}
+
+// AsFile - Returns the uploaded file as a `local_file` File, typed from its extension, to be sent with a
+// chat, completion or workflow request, or as the value of a file input variable.
+func (f *UploadedFile) AsFile() File {
+	return File{
+		Type:           FileTypeOf(f.Extension),
+		TransferMethod: TransferMethodLocalFile,
+		UploadFileID:   f.ID,
+	}
+}
+
+// UploadFile - Uploads a file read from r, to be used when sending messages or running workflows.
+// The name is used for the file extension, which must be supported by the application.
+func (c *Client) UploadFile(ctx context.Context, user, name string, r io.Reader) (*UploadedFile, error) {
+	request, err := c.newMultipartRequest(ctx, fileUploadEndpoint, map[string]string{"user": user}, "file", name, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var response UploadedFile
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
 }
@@ -0,0 +1,129 @@
+package dify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultIndexingPollInterval    = 2 * time.Second  // Default interval after the first poll of an indexing batch.
+	DefaultIndexingMaxPollInterval = 30 * time.Second // Default maximum interval between two polls of an indexing batch.
+)
+
+// IndexingStatus - Indexing progress of a document.
+type IndexingStatus struct {
+	ID                   string `json:"id"`                     // Document ID.
+	IndexingStatus       string `json:"indexing_status"`        // Indexing status, waiting/parsing/cleaning/splitting/indexing/completed/error/paused.
+	ProcessingStartedAt  int    `json:"processing_started_at"`  // Start time of the processing.
+	ParsingCompletedAt   int    `json:"parsing_completed_at"`   // End time of the parsing.
+	CleaningCompletedAt  int    `json:"cleaning_completed_at"`  // End time of the cleaning.
+	SplittingCompletedAt int    `json:"splitting_completed_at"` // End time of the splitting.
+	CompletedAt          int    `json:"completed_at"`           // End time of the indexing.
+	PausedAt             int    `json:"paused_at"`              // Pause time.
+	StoppedAt            int    `json:"stopped_at"`             // Stop time.
+	Error                string `json:"error"`                  // The reason for the indexing error.
+	CompletedSegments    int    `json:"completed_segments"`     // Number of segments indexed.
+	TotalSegments        int    `json:"total_segments"`         // Total number of segments.
+}
+
+// Done - Reports whether the indexing of the document is over, `completed`, `error` or `paused`.
+func (s IndexingStatus) Done() bool {
+	switch s.IndexingStatus {
+	case "completed", "error", "paused":
+		return true
+	}
+	return false
+}
+
+// IndexingOptions - Options of WaitForIndexing.
+type IndexingOptions struct {
+	PollInterval    time.Duration          // Interval after the first poll, DefaultIndexingPollInterval if 0.
+	MaxPollInterval time.Duration          // Maximum interval, doubled from PollInterval while nothing progresses, DefaultIndexingMaxPollInterval if 0.
+	OnProgress      func([]IndexingStatus) // Optional Called with the status of the documents after each poll.
+}
+
+// indexingStatusResponse - Response body from the indexing status endpoint.
+type indexingStatusResponse struct {
+	Data []IndexingStatus `json:"data"` // Status of the documents of the batch.
+}
+
+// GetIndexingStatus - Gets the indexing status of the documents of a batch.
+func (c *DatasetClient) GetIndexingStatus(ctx context.Context, datasetID, batch string) ([]IndexingStatus, error) {
+	request, err := c.client.newRequest(ctx, "GET", fmt.Sprintf("%s/%s/documents/%s/indexing-status", datasetEndpoint, datasetID, batch), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response indexingStatusResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// WaitForIndexing - Polls the indexing status of a batch until every document is `completed`, `error` or `paused`,
+// and returns the final status of the documents. The interval doubles up to opts.MaxPollInterval while no document
+// progresses. Documents that failed are returned with their Error, not as an error of WaitForIndexing.
+func (c *DatasetClient) WaitForIndexing(ctx context.Context, datasetID, batch string, opts IndexingOptions) ([]IndexingStatus, error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultIndexingPollInterval
+	}
+	maxInterval := opts.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultIndexingMaxPollInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	var previous []IndexingStatus
+	delay := interval
+	for {
+		statuses, err := c.GetIndexingStatus(ctx, datasetID, batch)
+		if err != nil {
+			return nil, err
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(statuses)
+		}
+
+		done := len(statuses) > 0
+		for _, status := range statuses {
+			done = done && status.Done()
+		}
+		if done {
+			return statuses, nil
+		}
+
+		if indexingProgressed(previous, statuses) {
+			delay = interval
+		} else if delay = 2 * delay; delay > maxInterval {
+			delay = maxInterval
+		}
+		previous = statuses
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// indexingProgressed - Reports whether a document changed status or indexed more segments since the previous poll.
+func indexingProgressed(previous, current []IndexingStatus) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+	for i := range current {
+		if current[i].IndexingStatus != previous[i].IndexingStatus || current[i].CompletedSegments != previous[i].CompletedSegments {
+			return true
+		}
+	}
+	return false
+}
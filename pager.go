@@ -0,0 +1,92 @@
+package dify
+
+import "context"
+
+// Pager - Iterator over the items of a paginated list endpoint, fetching the pages on demand.
+// Read it with Next and Current until Next returns false, or range over All with Go 1.23 or later.
+type Pager[T any] struct {
+	ctx     context.Context                                            // Context of the requests.
+	fetch   func(ctx context.Context, previous []T) ([]T, bool, error) // Fetches the page following previous, nil for the first page.
+	page    []T                                                        // Items of the current page.
+	index   int                                                        // Index of the next item in the current page.
+	hasMore bool                                                       // Whether there is a page after the current one.
+	started bool                                                       // Whether the first page has been fetched.
+	current T                                                          // Item returned by Current.
+	err     error                                                      // Error that stopped the iteration, returned by Err.
+}
+
+// newPager - Creates a pager fetching each page with fetch, which returns the items and whether more pages follow.
+func newPager[T any](ctx context.Context, fetch func(ctx context.Context, previous []T) ([]T, bool, error)) *Pager[T] {
+	return &Pager[T]{ctx: ctx, fetch: fetch}
+}
+
+// Next - Advances to the next item, fetching the next page when needed, returning false at the end or on error.
+func (p *Pager[T]) Next() bool {
+	for p.index >= len(p.page) {
+		if p.err != nil || (p.started && !p.hasMore) {
+			return false
+		}
+		if err := p.ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		items, hasMore, err := p.fetch(p.ctx, p.page)
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		p.started = true
+		p.page, p.index, p.hasMore = items, 0, hasMore && len(items) > 0
+	}
+
+	p.current = p.page[p.index]
+	p.index++
+	return true
+}
+
+// Current - Returns the item read by the last successful call to Next.
+func (p *Pager[T]) Current() T {
+	return p.current
+}
+
+// Err - Returns the error that stopped the iteration, or nil if all the pages were read.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All - Returns an iterator over the remaining items, compatible with iter.Seq2[T, error].
+// The error, if any, is yielded last with the zero value of T, breaking out of the loop stops fetching pages.
+func (p *Pager[T]) All() func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for p.Next() {
+			if !yield(p.Current(), nil) {
+				return
+			}
+		}
+		if err := p.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// newPagePager - Creates a pager over a page-numbered endpoint from startPage, 1 when not positive, fetching each page
+// with fetch, which returns the items of the page and whether more pages follow.
+func newPagePager[T any](ctx context.Context, startPage int, fetch func(ctx context.Context, page int) ([]T, bool, error)) *Pager[T] {
+	page := startPage
+	if page < 1 {
+		page = 1
+	}
+
+	return newPager(ctx, func(ctx context.Context, previous []T) ([]T, bool, error) {
+		items, hasMore, err := fetch(ctx, page)
+		if err != nil {
+			return nil, false, err
+		}
+
+		page++
+		return items, hasMore, nil
+	})
+}
@@ -0,0 +1,57 @@
+package dify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPagePager(t *testing.T) {
+	var pages []int
+	pager := newPagePager(context.Background(), 2, func(ctx context.Context, page int) ([]string, bool, error) {
+		pages = append(pages, page)
+		return []string{fmt.Sprintf("%d-a", page), fmt.Sprintf("%d-b", page)}, page < 3, nil
+	})
+
+	var items []string
+	for pager.Next() {
+		items = append(items, pager.Current())
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if want := "[2 3]"; fmt.Sprint(pages) != want {
+		t.Errorf("pages = %v, want %s", pages, want)
+	}
+	if want := "[2-a 2-b 3-a 3-b]"; fmt.Sprint(items) != want {
+		t.Errorf("items = %v, want %s", items, want)
+	}
+}
+
+func TestPagePagerError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	calls := 0
+	pager := newPagePager(context.Background(), 0, func(ctx context.Context, page int) ([]int, bool, error) {
+		calls++
+		if page > 1 {
+			return nil, false, errFetch
+		}
+		return []int{page}, true, nil
+	})
+
+	var items []int
+	for pager.Next() {
+		items = append(items, pager.Current())
+	}
+	if !errors.Is(pager.Err(), errFetch) {
+		t.Errorf("Err() = %v, want %v", pager.Err(), errFetch)
+	}
+	if fmt.Sprint(items) != "[1]" || calls != 2 {
+		t.Errorf("items = %v after %d calls, want [1] after 2 calls", items, calls)
+	}
+	if pager.Next() || calls != 2 {
+		t.Error("Next() fetched again after an error")
+	}
+}
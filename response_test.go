@@ -0,0 +1,46 @@
+package dify
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRatingMarshalJSON(t *testing.T) {
+	tests := []struct {
+		rating  Rating
+		want    string
+		wantErr bool
+	}{
+		{rating: RatingLike, want: `"like"`},
+		{rating: RatingDislike, want: `"dislike"`},
+		{rating: RatingNull, want: `null`},
+		{rating: "", wantErr: true},
+		{rating: "love", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.rating), func(t *testing.T) {
+			got, err := json.Marshal(tt.rating)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Marshal(%q) = %s, want an error", tt.rating, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Marshal(%q) error = %v", tt.rating, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal(%q) = %s, want %s", tt.rating, got, tt.want)
+			}
+
+			var decoded Rating
+			if err := json.Unmarshal(got, &decoded); err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", got, err)
+			}
+			if decoded != tt.rating {
+				t.Errorf("Unmarshal(%s) = %q, want %q", got, decoded, tt.rating)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package dify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Segment - Chunk of a document, the one cited by a RetrieverResource is fetched with GetRetrieverSegment.
+type Segment struct {
+	ID            string       `json:"id"`              // Segment ID, the SegmentID of the retriever resources.
+	Position      int          `json:"position"`        // Position in the document, unlike the Position of a retriever resource in its message.
+	DocumentID    string       `json:"document_id"`     // ID of the document.
+	Content       string       `json:"content"`         // Content of the segment.
+	Answer        string       `json:"answer"`          // Answer, for `qa_model` documents.
+	WordCount     int          `json:"word_count"`      // Number of words.
+	Tokens        int          `json:"tokens"`          // Number of tokens.
+	Keywords      []string     `json:"keywords"`        // Keywords of the segment.
+	IndexNodeID   string       `json:"index_node_id"`   // ID of the index node.
+	IndexNodeHash string       `json:"index_node_hash"` // Hash of the index node.
+	HitCount      int          `json:"hit_count"`       // Number of retrievals.
+	Enabled       bool         `json:"enabled"`         // Whether the segment is used for retrieval.
+	DisabledAt    int          `json:"disabled_at"`     // Timestamp of the disabling, 0 if enabled.
+	DisabledBy    string       `json:"disabled_by"`     // Account ID of who disabled the segment.
+	Status        string       `json:"status"`          // Indexing status, such as `indexing`, `completed` or `error`.
+	CreatedBy     string       `json:"created_by"`      // Account ID of the creator.
+	CreatedAt     int          `json:"created_at"`      // Creation timestamp.
+	IndexingAt    int          `json:"indexing_at"`     // Start time of the indexing.
+	CompletedAt   int          `json:"completed_at"`    // End time of the indexing.
+	Error         string       `json:"error"`           // The reason for the indexing error.
+	StoppedAt     int          `json:"stopped_at"`      // Stop time of the indexing.
+	ChildChunks   []ChildChunk `json:"child_chunks"`    // Child chunks, for `hierarchical_model` documents.
+}
+
+// ChildChunk - Child chunk of a segment, for parent-child indexed documents.
+type ChildChunk struct {
+	ID        string `json:"id"`         // Child chunk ID.
+	SegmentID string `json:"segment_id"` // ID of the parent segment.
+	Position  int    `json:"position"`   // Position in the segment.
+	Content   string `json:"content"`    // Content of the child chunk.
+	WordCount int    `json:"word_count"` // Number of words.
+	Type      string `json:"type"`       // Creation type, `automatic` or `customized`.
+	CreatedAt int    `json:"created_at"` // Creation timestamp.
+	UpdatedAt int    `json:"updated_at"` // Update timestamp.
+}
+
+// SegmentInput - Content of a segment to add.
+type SegmentInput struct {
+	Content  string   `json:"content"`            // Content of the segment, the question for `qa_model` documents.
+	Answer   string   `json:"answer,omitempty"`   // Optional Answer, for `qa_model` documents.
+	Keywords []string `json:"keywords,omitempty"` // Optional Keywords.
+}
+
+// UpdateSegmentRequest - Request body for updating a segment, only the fields set are updated.
+type UpdateSegmentRequest struct {
+	Content               string   `json:"content,omitempty"`                 // Optional Content of the segment.
+	Answer                string   `json:"answer,omitempty"`                  // Optional Answer, for `qa_model` documents.
+	Keywords              []string `json:"keywords,omitempty"`                // Optional Keywords.
+	Enabled               *bool    `json:"enabled,omitempty"`                 // Optional Whether the segment is used for retrieval.
+	RegenerateChildChunks bool     `json:"regenerate_child_chunks,omitempty"` // Optional Regenerate the child chunks from the new content.
+}
+
+// ListSegmentsRequest - Request parameters for listing the segments of a document.
+type ListSegmentsRequest struct {
+	Keyword string   // Optional Keyword to search in the contents.
+	Status  []string // Optional Only the segments with these indexing statuses, such as `completed`.
+	Page    int      // Page number, 1 by default.
+	Limit   int      // Number of records per page, 20 by default.
+}
+
+// SegmentsResponse - Response body from the ListSegments endpoint.
+type SegmentsResponse struct {
+	Data    []Segment `json:"data"`     // List of segments.
+	DocForm DocForm   `json:"doc_form"` // Chunk structure of the document.
+	HasMore bool      `json:"has_more"` // Whether there is a next page.
+	Limit   int       `json:"limit"`    // Number of records per page.
+	Total   int       `json:"total"`    // Total number of records.
+	Page    int       `json:"page"`     // Current page.
+}
+
+// ListChildChunksRequest - Request parameters for listing the child chunks of a segment.
+type ListChildChunksRequest struct {
+	Keyword string // Optional Keyword to search in the contents.
+	Page    int    // Page number, 1 by default.
+	Limit   int    // Number of records per page, 20 by default.
+}
+
+// ChildChunksResponse - Response body from the ListChildChunks endpoint.
+type ChildChunksResponse struct {
+	Data       []ChildChunk `json:"data"`        // List of child chunks.
+	Total      int          `json:"total"`       // Total number of records.
+	TotalPages int          `json:"total_pages"` // Total number of pages.
+	Page       int          `json:"page"`        // Current page.
+	Limit      int          `json:"limit"`       // Number of records per page.
+}
+
+// addSegmentsRequest - Request body for adding segments.
+type addSegmentsRequest struct {
+	Segments []SegmentInput `json:"segments"` // Segments to add.
+}
+
+// updateSegmentRequest - Request body for updating a segment.
+type updateSegmentRequest struct {
+	Segment UpdateSegmentRequest `json:"segment"` // Fields to update.
+}
+
+// childChunkRequest - Request body for creating or updating a child chunk.
+type childChunkRequest struct {
+	Content string `json:"content"` // Content of the child chunk.
+}
+
+// segmentsResponse - Response body from the AddSegments endpoint.
+type segmentsResponse struct {
+	Data []Segment `json:"data"` // Segments added.
+}
+
+// segmentResponse - Response body from the segment detail and update endpoints.
+type segmentResponse struct {
+	Data Segment `json:"data"` // Segment.
+}
+
+// childChunkResponse - Response body from the child chunk creation and update endpoints.
+type childChunkResponse struct {
+	Data ChildChunk `json:"data"` // Child chunk.
+}
+
+// segmentPath - Returns the endpoint path of the segments of a document, followed by the elements.
+func segmentPath(datasetID, documentID string, elements ...string) string {
+	path := fmt.Sprintf("%s/%s/documents/%s/segments", datasetEndpoint, datasetID, documentID)
+	for _, element := range elements {
+		path += "/" + element
+	}
+	return path
+}
+
+// AddSegments - Adds segments to a document of a knowledge base, the segments are then indexed.
+func (c *DatasetClient) AddSegments(ctx context.Context, datasetID, documentID string, segments []SegmentInput) ([]Segment, error) {
+	request, err := c.client.newRequest(ctx, "POST", segmentPath(datasetID, documentID), addSegmentsRequest{Segments: segments})
+	if err != nil {
+		return nil, err
+	}
+
+	var response segmentsResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// ListSegments - Lists the segments of a document of a knowledge base.
+func (c *DatasetClient) ListSegments(ctx context.Context, datasetID, documentID string, req ListSegmentsRequest) (*SegmentsResponse, error) {
+	query := url.Values{}
+	if req.Keyword != "" {
+		query.Set("keyword", req.Keyword)
+	}
+	for _, status := range req.Status {
+		query.Add("status", status)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	request, err := c.client.newRequest(ctx, "GET", segmentPath(datasetID, documentID)+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SegmentsResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Segments - Returns a pager over the segments of a document from req.Page, fetching req.Limit segments per page.
+func (c *DatasetClient) Segments(ctx context.Context, datasetID, documentID string, req ListSegmentsRequest) *Pager[Segment] {
+	return newPagePager(ctx, req.Page, func(ctx context.Context, page int) ([]Segment, bool, error) {
+		req.Page = page
+		response, err := c.ListSegments(ctx, datasetID, documentID, req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response.Data, response.HasMore, nil
+	})
+}
+
+// GetSegment - Gets the details of a segment.
+func (c *DatasetClient) GetSegment(ctx context.Context, datasetID, documentID, segmentID string) (*Segment, error) {
+	request, err := c.client.newRequest(ctx, "GET", segmentPath(datasetID, documentID, segmentID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response segmentResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// GetRetrieverSegment - Gets the details of the segment cited by a retriever resource of a message.
+func (c *DatasetClient) GetRetrieverSegment(ctx context.Context, resource RetrieverResource) (*Segment, error) {
+	return c.GetSegment(ctx, resource.DatasetID, resource.DocumentID, resource.SegmentID)
+}
+
+// UpdateSegment - Updates the content, answer, keywords or status of a segment, which is then indexed again.
+func (c *DatasetClient) UpdateSegment(ctx context.Context, datasetID, documentID, segmentID string, req UpdateSegmentRequest) (*Segment, error) {
+	request, err := c.client.newRequest(ctx, "POST", segmentPath(datasetID, documentID, segmentID), updateSegmentRequest{Segment: req})
+	if err != nil {
+		return nil, err
+	}
+
+	var response segmentResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// DeleteSegment - Deletes a segment of a document.
+func (c *DatasetClient) DeleteSegment(ctx context.Context, datasetID, documentID, segmentID string) error {
+	request, err := c.client.newRequest(ctx, "DELETE", segmentPath(datasetID, documentID, segmentID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.client.do(request, nil)
+}
+
+// ListChildChunks - Lists the child chunks of a segment of a parent-child indexed document.
+func (c *DatasetClient) ListChildChunks(ctx context.Context, datasetID, documentID, segmentID string, req ListChildChunksRequest) (*ChildChunksResponse, error) {
+	query := url.Values{}
+	if req.Keyword != "" {
+		query.Set("keyword", req.Keyword)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	request, err := c.client.newRequest(ctx, "GET", segmentPath(datasetID, documentID, segmentID, "child_chunks")+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ChildChunksResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ChildChunks - Returns a pager over the child chunks of a segment from req.Page, fetching req.Limit child chunks per page.
+func (c *DatasetClient) ChildChunks(ctx context.Context, datasetID, documentID, segmentID string, req ListChildChunksRequest) *Pager[ChildChunk] {
+	return newPagePager(ctx, req.Page, func(ctx context.Context, page int) ([]ChildChunk, bool, error) {
+		req.Page = page
+		response, err := c.ListChildChunks(ctx, datasetID, documentID, segmentID, req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response.Data, response.Page < response.TotalPages, nil
+	})
+}
+
+// CreateChildChunk - Adds a child chunk to a segment of a parent-child indexed document.
+func (c *DatasetClient) CreateChildChunk(ctx context.Context, datasetID, documentID, segmentID, content string) (*ChildChunk, error) {
+	request, err := c.client.newRequest(ctx, "POST", segmentPath(datasetID, documentID, segmentID, "child_chunks"), childChunkRequest{Content: content})
+	if err != nil {
+		return nil, err
+	}
+
+	var response childChunkResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// UpdateChildChunk - Updates the content of a child chunk.
+func (c *DatasetClient) UpdateChildChunk(ctx context.Context, datasetID, documentID, segmentID, childChunkID, content string) (*ChildChunk, error) {
+	request, err := c.client.newRequest(ctx, "PATCH", segmentPath(datasetID, documentID, segmentID, "child_chunks", childChunkID), childChunkRequest{Content: content})
+	if err != nil {
+		return nil, err
+	}
+
+	var response childChunkResponse
+	if err := c.client.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// DeleteChildChunk - Deletes a child chunk of a segment.
+func (c *DatasetClient) DeleteChildChunk(ctx context.Context, datasetID, documentID, segmentID, childChunkID string) error {
+	request, err := c.client.newRequest(ctx, "DELETE", segmentPath(datasetID, documentID, segmentID, "child_chunks", childChunkID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.client.do(request, nil)
+}
@@ -0,0 +1,39 @@
+package dify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRetrieverSegment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/v1/datasets/ds-1/documents/doc-1/segments/seg-1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"seg-1","position":3,"document_id":"doc-1","content":"Dify is an LLM app platform.","keywords":["dify"]},"doc_form":"text_model"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewDatasetClient(ClientConfig{BaseURL: server.URL, APIKey: "dataset-key"})
+	if err != nil {
+		t.Fatalf("NewDatasetClient() error = %v", err)
+	}
+
+	resource := RetrieverResource{Position: 1, DatasetID: "ds-1", DocumentID: "doc-1", SegmentID: "seg-1", Content: "LLM app platform"}
+	segment, err := client.GetRetrieverSegment(context.Background(), resource)
+	if err != nil {
+		t.Fatalf("GetRetrieverSegment() error = %v", err)
+	}
+	if segment.ID != "seg-1" || segment.DocumentID != "doc-1" {
+		t.Errorf("segment = %s/%s, want doc-1/seg-1", segment.DocumentID, segment.ID)
+	}
+	if want := "Dify is an LLM app platform."; segment.Content != want {
+		t.Errorf("Content = %q, want %q", segment.Content, want)
+	}
+	if segment.Position != 3 || len(segment.Keywords) != 1 || segment.Keywords[0] != "dify" {
+		t.Errorf("Position, Keywords = %d, %v, want 3, [dify]", segment.Position, segment.Keywords)
+	}
+}
@@ -0,0 +1,141 @@
+package dify
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// DefaultMaxEventSize - Default maximum size in bytes of a single Server-Sent Event, counting every byte of its lines
+// as received, field names and comments included, but not the line terminators.
+const DefaultMaxEventSize = 16 << 20
+
+// ErrEventTooLarge - Returned by a stream when an event exceeds the maximum event size.
+var ErrEventTooLarge = errors.New("stream event exceeds the maximum event size")
+
+// sseEvent - Event dispatched by the Server-Sent Events decoder.
+type sseEvent struct {
+	Event string // Event type, `message` when the event field is absent.
+	Data  []byte // Event data, multiple data lines are joined with a newline.
+	ID    string // Last event ID.
+	Retry int    // Reconnection time in milliseconds, 0 when not set.
+}
+
+// sseDecoder - Server-Sent Events decoder following the WHATWG event stream interpretation rules.
+type sseDecoder struct {
+	reader       *bufio.Reader // Buffered reader over the event stream.
+	maxEventSize int           // Maximum size in bytes of the lines of a single event, line terminators excluded.
+	lastID       string        // Last event ID buffer, kept across events.
+	retry        int           // Last reconnection time received.
+	skipLF       bool          // Whether a line feed following a carriage return must be skipped.
+	line         []byte        // Buffer of the line being read.
+}
+
+// newSSEDecoder - Creates a decoder reading events from r, using DefaultMaxEventSize when maxEventSize is not positive.
+func newSSEDecoder(r io.Reader, maxEventSize int) *sseDecoder {
+	if maxEventSize <= 0 {
+		maxEventSize = DefaultMaxEventSize
+	}
+	return &sseDecoder{
+		reader:       bufio.NewReader(r),
+		maxEventSize: maxEventSize,
+	}
+}
+
+// Next - Reads until the next event is dispatched, returning io.EOF at the end of the stream.
+func (d *sseDecoder) Next() (sseEvent, error) {
+	var (
+		event   string
+		data    bytes.Buffer
+		hasData bool
+		size    int // Bytes of the lines read since the last blank line.
+	)
+
+	for {
+		line, err := d.readLine(d.maxEventSize - size)
+		if err != nil {
+			// An incomplete event at the end of the stream is discarded.
+			return sseEvent{}, err
+		}
+		size += len(line)
+
+		// A blank line dispatches the event.
+		if len(line) == 0 {
+			if !hasData {
+				event = ""
+				size = 0
+				continue
+			}
+			if event == "" {
+				event = "message"
+			}
+			return sseEvent{Event: event, Data: data.Bytes(), ID: d.lastID, Retry: d.retry}, nil
+		}
+
+		// Lines starting with a colon are comments, used by servers as keep-alive.
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value := line, []byte(nil)
+		if i := bytes.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], line[i+1:]
+			value = bytes.TrimPrefix(value, []byte(" "))
+		}
+
+		switch string(field) {
+		case "event":
+			event = string(value)
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.Write(value)
+			hasData = true
+		case "id":
+			if bytes.IndexByte(value, 0) < 0 {
+				d.lastID = string(value)
+			}
+		case "retry":
+			if retry, err := strconv.Atoi(string(value)); err == nil && retry >= 0 {
+				d.retry = retry
+			}
+		}
+	}
+}
+
+// readLine - Reads a line terminated by CRLF, LF or CR, failing with ErrEventTooLarge if it exceeds limit bytes.
+func (d *sseDecoder) readLine(limit int) ([]byte, error) {
+	d.line = d.line[:0]
+	for {
+		b, err := d.reader.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(d.line) > 0 {
+				return d.line, nil
+			}
+			return nil, err
+		}
+
+		if d.skipLF {
+			d.skipLF = false
+			if b == '\n' {
+				continue
+			}
+		}
+
+		switch b {
+		case '\n':
+			return d.line, nil
+		case '\r':
+			d.skipLF = true
+			return d.line, nil
+		}
+
+		if len(d.line) >= limit {
+			return nil, ErrEventTooLarge
+		}
+		d.line = append(d.line, b)
+	}
+}
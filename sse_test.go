@@ -0,0 +1,154 @@
+package dify
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSSEDecoderNext(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		maxEventSize int
+		want         []sseEvent
+		wantErr      error
+	}{
+		{
+			name:  "LF line endings",
+			input: "event: message\ndata: hello\n\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("hello")}},
+		},
+		{
+			name:  "CRLF line endings",
+			input: "event: ping\r\ndata: a\r\n\r\ndata: b\r\n\r\n",
+			want:  []sseEvent{{Event: "ping", Data: []byte("a")}, {Event: "message", Data: []byte("b")}},
+		},
+		{
+			name:  "CR line endings",
+			input: "data: a\r\rdata: b\r\r",
+			want:  []sseEvent{{Event: "message", Data: []byte("a")}, {Event: "message", Data: []byte("b")}},
+		},
+		{
+			name:  "mixed line endings",
+			input: "data: a\r\ndata: b\rdata: c\n\r\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("a\nb\nc")}},
+		},
+		{
+			name:  "multi-line data",
+			input: "data: {\"answer\":\ndata: \"hi\"}\n\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("{\"answer\":\n\"hi\"}")}},
+		},
+		{
+			name:  "comments are ignored",
+			input: ": keep-alive\ndata: a\n: another\n\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("a")}},
+		},
+		{
+			name:  "only the first space of the value is removed",
+			input: "data:a\n\ndata:  b\n\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("a")}, {Event: "message", Data: []byte(" b")}},
+		},
+		{
+			name:  "field without colon",
+			input: "data\n\n",
+			want:  []sseEvent{{Event: "message"}},
+		},
+		{
+			name:  "events without data are not dispatched",
+			input: "event: ping\n\ndata: a\n\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("a")}},
+		},
+		{
+			name:  "unknown fields are ignored",
+			input: "foo: bar\ndata: a\n\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("a")}},
+		},
+		{
+			name:  "id is kept across events",
+			input: "id: 1\ndata: a\n\ndata: b\n\nid\ndata: c\n\n",
+			want: []sseEvent{
+				{Event: "message", Data: []byte("a"), ID: "1"},
+				{Event: "message", Data: []byte("b"), ID: "1"},
+				{Event: "message", Data: []byte("c")},
+			},
+		},
+		{
+			name:  "id containing NUL is ignored",
+			input: "id: 1\ndata: a\n\nid: 2\x003\ndata: b\n\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("a"), ID: "1"}, {Event: "message", Data: []byte("b"), ID: "1"}},
+		},
+		{
+			name:  "retry",
+			input: "retry: 3000\ndata: a\n\nretry: soon\ndata: b\n\n",
+			want:  []sseEvent{{Event: "message", Data: []byte("a"), Retry: 3000}, {Event: "message", Data: []byte("b"), Retry: 3000}},
+		},
+		{
+			name:  "incomplete event at the end is discarded",
+			input: "data: a\n\ndata: b",
+			want:  []sseEvent{{Event: "message", Data: []byte("a")}},
+		},
+		{
+			name:         "event at the size limit",
+			input:        "data: 0123456789\n\n",
+			maxEventSize: 16,
+			want:         []sseEvent{{Event: "message", Data: []byte("0123456789")}},
+		},
+		{
+			name:         "event over the size limit",
+			input:        "data: 0123456789a\n\n",
+			maxEventSize: 16,
+			wantErr:      ErrEventTooLarge,
+		},
+		{
+			name:         "lines at the size limit",
+			input:        "event: x\ndata: a\n\ndata: 012345678\n\n",
+			maxEventSize: 15,
+			want:         []sseEvent{{Event: "x", Data: []byte("a")}, {Event: "message", Data: []byte("012345678")}},
+		},
+		{
+			name:         "data lines over the size limit",
+			input:        "data: 01234\ndata: a\n\n",
+			maxEventSize: 16,
+			wantErr:      ErrEventTooLarge,
+		},
+		{
+			name:         "comment and event lines over the size limit",
+			input:        ": ping\nevent: x\ndata: a\n\n",
+			maxEventSize: 16,
+			wantErr:      ErrEventTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := newSSEDecoder(strings.NewReader(tt.input), tt.maxEventSize)
+
+			var got []sseEvent
+			var err error
+			for {
+				var event sseEvent
+				event, err = decoder.Next()
+				if err != nil {
+					break
+				}
+				got = append(got, event)
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Next() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("Next() error = %v, want io.EOF", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
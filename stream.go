@@ -0,0 +1,153 @@
+package dify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Stream - Events decoded from a streaming response, read with Next and Current until Next returns false.
+type Stream[T any] struct {
+	ctx     context.Context           // Context of the request, checked before every read.
+	body    io.ReadCloser             // Response body, owned by the stream.
+	decoder *sseDecoder               // Server-Sent Events decoder over the response body.
+	decode  func(sseEvent) (T, error) // Decodes an SSE event into a value of the stream.
+	current T                         // Value returned by Current.
+	err     error                     // First error encountered, returned by Err.
+	done    atomic.Bool               // Whether the stream has ended, set by Close from any goroutine.
+
+	stopAfter func() bool // Cancels closing the body when the context is done.
+	closeOnce sync.Once   // Guards closing the body.
+	closeErr  error       // Error returned by closing the body.
+
+	mu     sync.Mutex                                     // Guards taskID.
+	taskID string                                         // First task ID observed on the stream.
+	stop   func(ctx context.Context, taskID string) error // Stops the generation of the task, nil if not supported.
+}
+
+// stopRequest - Request body for stopping a generation task.
+type stopRequest struct {
+	User string `json:"user"` // Identity of the end user, must be consistent with the one of the generation.
+}
+
+// newStream - Creates a stream that owns the response body, limiting each event to maxEventSize bytes.
+// The body is closed when the stream ends or fails, when Close is called, or as soon as the context is done.
+func newStream[T any](ctx context.Context, body io.ReadCloser, maxEventSize int, decode func(sseEvent) (T, error)) *Stream[T] {
+	return &Stream[T]{
+		ctx:       ctx,
+		body:      body,
+		decoder:   newSSEDecoder(body, maxEventSize),
+		decode:    decode,
+		stopAfter: context.AfterFunc(ctx, func() { body.Close() }),
+	}
+}
+
+// Next - Advances to the next value, returning false when the stream is finished, failed or the context is done.
+// An error event is returned as a value, then the following call returns false.
+func (s *Stream[T]) Next() bool {
+	if s.done.Load() {
+		return false
+	}
+	if s.err != nil {
+		return s.finish(s.err)
+	}
+	if err := s.ctx.Err(); err != nil {
+		return s.finish(err)
+	}
+
+	event, err := s.decoder.Next()
+	if errors.Is(err, io.EOF) {
+		return s.finish(nil)
+	}
+	if err != nil {
+		if ctxErr := s.ctx.Err(); ctxErr != nil {
+			return s.finish(ctxErr)
+		}
+		if s.done.Load() {
+			// Closed while reading, the stream ends as if it had completed.
+			return s.finish(nil)
+		}
+		return s.finish(fmt.Errorf("failed to read response body: %w", err))
+	}
+
+	value, err := s.decode(event)
+	if err != nil {
+		return s.finish(err)
+	}
+
+	s.current = value
+	s.observeTaskID(value)
+	if event, ok := any(value).(*ErrorEvent); ok {
+		// The error event is returned, then ends the stream with its error.
+		s.err = event.APIError()
+	}
+	return true
+}
+
+// Current - Returns the value read by the last successful call to Next.
+func (s *Stream[T]) Current() T {
+	return s.current
+}
+
+// Err - Returns the error that stopped the stream, or nil if it finished normally. An error event sent by Dify
+// ends the stream with its *APIError, available as soon as the event has been read.
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// TaskID - Returns the first task ID observed on the stream, empty until an event carrying it has been read.
+func (s *Stream[T]) TaskID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.taskID
+}
+
+// Stop - Stops the generation of the task, it can be called from another goroutine while the stream is read.
+// The stream then ends with the events sent by Dify after stopping, such as message_end or workflow_finished.
+func (s *Stream[T]) Stop(ctx context.Context) error {
+	if s.stop == nil {
+		return fmt.Errorf("stream does not support stopping")
+	}
+
+	taskID := s.TaskID()
+	if taskID == "" {
+		return fmt.Errorf("task ID not received yet")
+	}
+
+	return s.stop(ctx, taskID)
+}
+
+// Close - Closes the response body, it is safe to call at any time and more than once, including from another
+// goroutine while Next is reading, which then returns false with a nil Err.
+func (s *Stream[T]) Close() error {
+	s.done.Store(true)
+	s.closeOnce.Do(func() {
+		s.stopAfter()
+		s.closeErr = s.body.Close()
+	})
+	return s.closeErr
+}
+
+// finish - Ends the stream with err, which is nil when the stream completed, and releases the response body.
+func (s *Stream[T]) finish(err error) bool {
+	s.err = err
+	s.Close()
+	return false
+}
+
+// observeTaskID - Records the task ID of the first event carrying one.
+func (s *Stream[T]) observeTaskID(value T) {
+	event, ok := any(value).(Event)
+	if !ok || event.EventTaskID() == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.taskID == "" {
+		s.taskID = event.EventTaskID()
+	}
+}
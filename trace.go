@@ -0,0 +1,242 @@
+package dify
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TraceNode - Execution of a node in a workflow trace.
+type TraceNode struct {
+	ID                  string                 // Node execution ID.
+	NodeID              string                 // Node ID, shared by all the executions of the node.
+	NodeType            string                 // Node type, such as: llm, code, tool, etc.
+	Title               string                 // Node name.
+	Index               int                    // Execution sequence number.
+	Inputs              map[string]interface{} // All the previous node variables used in the node.
+	ProcessData         map[string]interface{} // Node process data.
+	Outputs             map[string]interface{} // Output content.
+	Status              string                 // Execution status running/succeeded/failed/stopped.
+	Error               string                 // The reason for the error.
+	ElapsedTime         float64                // Time consumed (s).
+	TotalTokens         int                    // Total tokens used.
+	TotalPrice          json.Number            // Total cost.
+	Currency            string                 // Currency, such as USD/RMB.
+	CreatedAt           int                    // Start time.
+	FinishedAt          int                    // End time, 0 while running.
+	IterationIndex      int                    // Index of the iteration round, for nodes inside an iteration.
+	LoopIndex           int                    // Index of the loop round, for nodes inside a loop.
+	ParallelID          string                 // ID of the parallel branch group running the node, empty outside parallels.
+	ParallelStartNodeID string                 // Node ID starting the parallel branch running the node.
+	Steps               int                    // Number of rounds, for iteration and loop nodes.
+	Retries             int                    // Number of failed attempts retried before this execution.
+
+	Predecessor *TraceNode   // Latest execution of the predecessor node when the node started, nil for the start node.
+	Parent      *TraceNode   // Iteration or loop node execution containing the node, nil at the top level.
+	Children    []*TraceNode // Node executions inside the iteration or loop, for iteration and loop nodes.
+}
+
+// WorkflowTrace - Complete record of a workflow execution built from the events of a workflow stream.
+// Add the events as they are read to follow the progress, or Collect a whole stream.
+type WorkflowTrace struct {
+	TaskID        string                 // Task ID.
+	WorkflowRunID string                 // Workflow execution ID.
+	WorkflowID    string                 // Associated Workflow ID, the version that runs.
+	Inputs        map[string]interface{} // Inputs of the workflow.
+	Nodes         []*TraceNode           // All node executions, in start order.
+
+	finished *WorkflowFinishedEvent // Event ending the workflow, nil while running.
+	err      error                  // Error event received from the stream.
+	byID     map[string]*TraceNode  // Node executions by execution ID.
+	latest   map[string]*TraceNode  // Latest execution by node ID.
+}
+
+// NewWorkflowTrace - Creates an empty trace.
+func NewWorkflowTrace() *WorkflowTrace {
+	return &WorkflowTrace{
+		byID:   make(map[string]*TraceNode),
+		latest: make(map[string]*TraceNode),
+	}
+}
+
+// Collect - Adds all the events of the stream to the trace, then closes the stream.
+func (t *WorkflowTrace) Collect(stream *Stream[Event]) error {
+	defer stream.Close()
+	for stream.Next() {
+		t.Add(stream.Current())
+	}
+	return stream.Err()
+}
+
+// Add - Records a workflow event in the trace, other events are ignored.
+func (t *WorkflowTrace) Add(event Event) {
+	switch e := event.(type) {
+	case *WorkflowStartedEvent:
+		t.TaskID, t.WorkflowRunID = e.TaskID, e.WorkflowRunID
+		t.WorkflowID, t.Inputs = e.Data.WorkflowID, e.Data.Inputs
+	case *NodeStartedEvent:
+		node := t.node(e.Data.ID)
+		node.NodeID, node.NodeType, node.Title = e.Data.NodeID, e.Data.NodeType, e.Data.Title
+		node.Index, node.Inputs, node.CreatedAt = e.Data.Index, e.Data.Inputs, e.Data.CreatedAt
+		node.Status = "running"
+		node.ParallelID, node.ParallelStartNodeID = e.Data.ParallelID, e.Data.ParallelStartNodeID
+		if e.Data.PredecessorNodeID != "" {
+			node.Predecessor = t.latest[e.Data.PredecessorNodeID]
+		}
+		t.setParent(node, e.Data.IterationID, e.Data.LoopID)
+		t.latest[node.NodeID] = node
+	case *NodeRetryEvent:
+		if node, ok := t.latest[e.Data.NodeID]; ok {
+			node.Retries++
+		}
+	case *NodeFinishedEvent:
+		node := t.node(e.Data.ID)
+		node.NodeID, node.NodeType, node.Title, node.Index = e.Data.NodeID, e.Data.NodeType, e.Data.Title, e.Data.Index
+		node.Inputs, node.ProcessData, node.Outputs = e.Data.Inputs, e.Data.ProcessData, e.Data.Outputs
+		node.Status, node.Error, node.ElapsedTime = e.Data.Status, e.Data.Error, e.Data.ElapsedTime
+		node.CreatedAt, node.FinishedAt = e.Data.CreatedAt, e.Data.FinishedAt
+
+		metadata := e.Data.ExecutionMetadata
+		node.TotalTokens, node.TotalPrice, node.Currency = metadata.TotalTokens, metadata.TotalPrice, metadata.Currency
+		node.IterationIndex, node.LoopIndex = metadata.IterationIndex, metadata.LoopIndex
+		node.ParallelID, node.ParallelStartNodeID = e.Data.ParallelID, e.Data.ParallelStartNodeID
+		if node.ParallelID == "" {
+			node.ParallelID, node.ParallelStartNodeID = metadata.ParallelID, metadata.ParallelStartNodeID
+		}
+		if node.Predecessor == nil && e.Data.PredecessorNodeID != "" {
+			node.Predecessor = t.latest[e.Data.PredecessorNodeID]
+		}
+		iterationID, loopID := e.Data.IterationID, e.Data.LoopID
+		if iterationID == "" && loopID == "" {
+			iterationID, loopID = metadata.IterationID, metadata.LoopID
+		}
+		t.setParent(node, iterationID, loopID)
+		if _, ok := t.latest[node.NodeID]; !ok {
+			t.latest[node.NodeID] = node
+		}
+	case *IterationStartedEvent:
+		node := t.node(e.Data.ID)
+		node.NodeID, node.NodeType, node.Title = e.Data.NodeID, e.Data.NodeType, e.Data.Title
+		node.Inputs, node.CreatedAt, node.Status = e.Data.Inputs, e.Data.CreatedAt, "running"
+		node.ParallelID, node.ParallelStartNodeID = e.Data.ParallelID, e.Data.ParallelStartNodeID
+		t.latest[node.NodeID] = node
+	case *IterationCompletedEvent:
+		node := t.node(e.Data.ID)
+		node.NodeID, node.NodeType, node.Title = e.Data.NodeID, e.Data.NodeType, e.Data.Title
+		node.Inputs, node.Outputs, node.Steps = e.Data.Inputs, e.Data.Outputs, e.Data.Steps
+		node.Status, node.Error, node.ElapsedTime = e.Data.Status, e.Data.Error, e.Data.ElapsedTime
+		node.CreatedAt, node.FinishedAt = e.Data.CreatedAt, e.Data.FinishedAt
+		node.TotalTokens, node.TotalPrice, node.Currency = e.Data.TotalTokens, e.Data.ExecutionMetadata.TotalPrice, e.Data.ExecutionMetadata.Currency
+		node.ParallelID, node.ParallelStartNodeID = e.Data.ParallelID, e.Data.ParallelStartNodeID
+		if _, ok := t.latest[node.NodeID]; !ok {
+			t.latest[node.NodeID] = node
+		}
+	case *LoopStartedEvent:
+		node := t.node(e.Data.ID)
+		node.NodeID, node.NodeType, node.Title = e.Data.NodeID, e.Data.NodeType, e.Data.Title
+		node.Inputs, node.CreatedAt, node.Status = e.Data.Inputs, e.Data.CreatedAt, "running"
+		node.ParallelID, node.ParallelStartNodeID = e.Data.ParallelID, e.Data.ParallelStartNodeID
+		t.latest[node.NodeID] = node
+	case *LoopCompletedEvent:
+		node := t.node(e.Data.ID)
+		node.NodeID, node.NodeType, node.Title = e.Data.NodeID, e.Data.NodeType, e.Data.Title
+		node.Inputs, node.Outputs, node.Steps = e.Data.Inputs, e.Data.Outputs, e.Data.Steps
+		node.Status, node.Error, node.ElapsedTime = e.Data.Status, e.Data.Error, e.Data.ElapsedTime
+		node.CreatedAt, node.FinishedAt = e.Data.CreatedAt, e.Data.FinishedAt
+		node.TotalTokens, node.TotalPrice, node.Currency = e.Data.TotalTokens, e.Data.ExecutionMetadata.TotalPrice, e.Data.ExecutionMetadata.Currency
+		node.ParallelID, node.ParallelStartNodeID = e.Data.ParallelID, e.Data.ParallelStartNodeID
+		if _, ok := t.latest[node.NodeID]; !ok {
+			t.latest[node.NodeID] = node
+		}
+	case *WorkflowFinishedEvent:
+		t.finished = e
+		if t.WorkflowRunID == "" {
+			t.TaskID, t.WorkflowRunID, t.WorkflowID = e.TaskID, e.WorkflowRunID, e.Data.WorkflowID
+		}
+	case *ErrorEvent:
+		t.err = e.APIError()
+	}
+}
+
+// Finished - Reports whether the workflow_finished event has been received.
+func (t *WorkflowTrace) Finished() bool {
+	return t.finished != nil
+}
+
+// Roots - Returns the node executions at the top level of the workflow, outside iterations and loops.
+func (t *WorkflowTrace) Roots() []*TraceNode {
+	var roots []*TraceNode
+	for _, node := range t.Nodes {
+		if node.Parent == nil {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+// Branches - Returns the node executions of each parallel branch, by parallel start node ID.
+func (t *WorkflowTrace) Branches(parallelID string) map[string][]*TraceNode {
+	branches := make(map[string][]*TraceNode)
+	for _, node := range t.Nodes {
+		if node.ParallelID == parallelID {
+			branches[node.ParallelStartNodeID] = append(branches[node.ParallelStartNodeID], node)
+		}
+	}
+	return branches
+}
+
+// TotalTokens - Returns the tokens used by the node executions so far, or by the workflow once finished.
+func (t *WorkflowTrace) TotalTokens() int {
+	if t.finished != nil {
+		return t.finished.Data.TotalTokens
+	}
+	total := 0
+	for _, node := range t.Nodes {
+		// The tokens of iterations and loops are those of their children.
+		if len(node.Children) == 0 {
+			total += node.TotalTokens
+		}
+	}
+	return total
+}
+
+// Result - Returns the result of the workflow exactly as RunWorkflow would, once the workflow_finished event
+// has been received, or the error event of the stream.
+func (t *WorkflowTrace) Result() (*CompletionResponse, error) {
+	if t.finished != nil {
+		return &CompletionResponse{WorkflowRunID: t.finished.WorkflowRunID, TaskID: t.finished.TaskID, Data: t.finished.Data}, nil
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	return nil, fmt.Errorf("workflow not finished")
+}
+
+// node - Returns the node execution with the ID, creating it on the first event.
+func (t *WorkflowTrace) node(id string) *TraceNode {
+	node, ok := t.byID[id]
+	if !ok {
+		node = &TraceNode{ID: id}
+		t.byID[id] = node
+		t.Nodes = append(t.Nodes, node)
+	}
+	return node
+}
+
+// setParent - Attaches the node to the latest execution of the iteration or loop node containing it.
+func (t *WorkflowTrace) setParent(node *TraceNode, iterationID, loopID string) {
+	if node.Parent != nil {
+		return
+	}
+
+	parentID := iterationID
+	if parentID == "" {
+		parentID = loopID
+	}
+	parent, ok := t.latest[parentID]
+	if parentID == "" || !ok || parent == node {
+		return
+	}
+
+	node.Parent = parent
+	parent.Children = append(parent.Children, node)
+}
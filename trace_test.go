@@ -0,0 +1,168 @@
+package dify
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// traceEvents - Decodes the JSON payloads of workflow events.
+func traceEvents(t *testing.T, payloads []string) []Event {
+	t.Helper()
+
+	events := make([]Event, len(payloads))
+	for i, payload := range payloads {
+		event, err := decodeEvent(sseEvent{Event: "message", Data: []byte(payload)})
+		if err != nil {
+			t.Fatalf("decodeEvent(%s) error = %v", payload, err)
+		}
+		events[i] = event
+	}
+	return events
+}
+
+// traceIDs - Returns the execution IDs of the nodes.
+func traceIDs(nodes []*TraceNode) []string {
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.ID
+	}
+	return ids
+}
+
+func TestWorkflowTrace(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []string
+		nodes    []string                       // Execution IDs of all the nodes, in start order.
+		roots    []string                       // Execution IDs of the roots.
+		children map[string][]string            // Execution IDs of the children, by parent execution ID.
+		branches map[string]map[string][]string // Execution IDs of the branches, by parallel ID and start node ID.
+		steps    map[string]int                 // Rounds, by execution ID.
+		tokens   int                            // Total tokens before the workflow finished.
+	}{
+		{
+			name: "iteration",
+			events: []string{
+				`{"event":"workflow_started","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"run-1","workflow_id":"wf-1"}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"start-1","node_id":"start","node_type":"start","index":1}}`,
+				`{"event":"node_finished","workflow_run_id":"run-1","data":{"id":"start-1","node_id":"start","node_type":"start","index":1,"status":"succeeded"}}`,
+				`{"event":"iteration_started","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","title":"Iterate"}}`,
+				`{"event":"iteration_next","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","index":0}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"llm-1","node_id":"llm","node_type":"llm","iteration_id":"iter"}}`,
+				`{"event":"node_finished","workflow_run_id":"run-1","data":{"id":"llm-1","node_id":"llm","node_type":"llm","iteration_id":"iter","status":"succeeded","execution_metadata":{"total_tokens":10,"iteration_id":"iter","iteration_index":0}}}`,
+				`{"event":"iteration_next","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","index":1}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"llm-2","node_id":"llm","node_type":"llm","iteration_id":"iter"}}`,
+				`{"event":"node_finished","workflow_run_id":"run-1","data":{"id":"llm-2","node_id":"llm","node_type":"llm","iteration_id":"iter","status":"succeeded","execution_metadata":{"total_tokens":20,"iteration_id":"iter","iteration_index":1}}}`,
+				`{"event":"iteration_completed","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","title":"Iterate","status":"succeeded","steps":2,"total_tokens":30,"outputs":{"output":["a","b"]}}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"end-1","node_id":"end","node_type":"end","predecessor_node_id":"iter"}}`,
+			},
+			nodes:    []string{"start-1", "iter-1", "llm-1", "llm-2", "end-1"},
+			roots:    []string{"start-1", "iter-1", "end-1"},
+			children: map[string][]string{"iter-1": {"llm-1", "llm-2"}},
+			branches: map[string]map[string][]string{},
+			steps:    map[string]int{"iter-1": 2},
+			tokens:   30,
+		},
+		{
+			name: "loop",
+			events: []string{
+				`{"event":"loop_started","workflow_run_id":"run-1","data":{"id":"loop-1","node_id":"loop","node_type":"loop"}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"code-1","node_id":"code","node_type":"code","loop_id":"loop"}}`,
+				`{"event":"node_finished","workflow_run_id":"run-1","data":{"id":"code-1","node_id":"code","node_type":"code","loop_id":"loop","status":"succeeded"}}`,
+				`{"event":"loop_next","workflow_run_id":"run-1","data":{"id":"loop-1","node_id":"loop","node_type":"loop","index":1}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"code-2","node_id":"code","node_type":"code","loop_id":"loop"}}`,
+				`{"event":"loop_completed","workflow_run_id":"run-1","data":{"id":"loop-1","node_id":"loop","node_type":"loop","status":"succeeded","steps":2}}`,
+			},
+			nodes:    []string{"loop-1", "code-1", "code-2"},
+			roots:    []string{"loop-1"},
+			children: map[string][]string{"loop-1": {"code-1", "code-2"}},
+			branches: map[string]map[string][]string{},
+			steps:    map[string]int{"loop-1": 2},
+		},
+		{
+			name: "parallel branches",
+			events: []string{
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"start-1","node_id":"start","node_type":"start"}}`,
+				`{"event":"parallel_branch_started","workflow_run_id":"run-1","data":{"parallel_id":"p1","parallel_branch_id":"a"}}`,
+				`{"event":"parallel_branch_started","workflow_run_id":"run-1","data":{"parallel_id":"p1","parallel_branch_id":"b"}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"a-1","node_id":"a","node_type":"llm","parallel_id":"p1","parallel_start_node_id":"a"}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"b-1","node_id":"b","node_type":"llm","parallel_id":"p1","parallel_start_node_id":"b"}}`,
+				`{"event":"node_finished","workflow_run_id":"run-1","data":{"id":"b-1","node_id":"b","node_type":"llm","parallel_id":"p1","parallel_start_node_id":"b","status":"succeeded","execution_metadata":{"total_tokens":5}}}`,
+				`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"a-2","node_id":"a2","node_type":"code","parallel_id":"p1","parallel_start_node_id":"a","predecessor_node_id":"a"}}`,
+				`{"event":"parallel_branch_finished","workflow_run_id":"run-1","data":{"parallel_id":"p1","parallel_branch_id":"b","status":"succeeded"}}`,
+			},
+			nodes:    []string{"start-1", "a-1", "b-1", "a-2"},
+			roots:    []string{"start-1", "a-1", "b-1", "a-2"},
+			children: map[string][]string{},
+			branches: map[string]map[string][]string{"p1": {"a": {"a-1", "a-2"}, "b": {"b-1"}}},
+			steps:    map[string]int{},
+			tokens:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := NewWorkflowTrace()
+			for _, event := range traceEvents(t, tt.events) {
+				trace.Add(event)
+			}
+
+			if got := traceIDs(trace.Nodes); !reflect.DeepEqual(got, tt.nodes) {
+				t.Errorf("Nodes = %v, want %v", got, tt.nodes)
+			}
+			if got := traceIDs(trace.Roots()); !reflect.DeepEqual(got, tt.roots) {
+				t.Errorf("Roots() = %v, want %v", got, tt.roots)
+			}
+			for _, node := range trace.Nodes {
+				if got, want := traceIDs(node.Children), tt.children[node.ID]; len(got) > 0 || len(want) > 0 {
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("%s Children = %v, want %v", node.ID, got, want)
+					}
+				}
+				for _, child := range node.Children {
+					if child.Parent != node {
+						t.Errorf("%s Parent = %v, want %s", child.ID, child.Parent, node.ID)
+					}
+				}
+				if node.Steps != tt.steps[node.ID] {
+					t.Errorf("%s Steps = %d, want %d", node.ID, node.Steps, tt.steps[node.ID])
+				}
+			}
+			for parallelID, want := range tt.branches {
+				branches := make(map[string][]string)
+				for startNodeID, nodes := range trace.Branches(parallelID) {
+					branches[startNodeID] = traceIDs(nodes)
+				}
+				if !reflect.DeepEqual(branches, want) {
+					t.Errorf("Branches(%s) = %v, want %v", parallelID, branches, want)
+				}
+			}
+			if got := trace.TotalTokens(); got != tt.tokens {
+				t.Errorf("TotalTokens() = %d, want %d", got, tt.tokens)
+			}
+		})
+	}
+}
+
+func TestWorkflowTraceIterationNode(t *testing.T) {
+	trace := NewWorkflowTrace()
+	for _, event := range traceEvents(t, []string{
+		`{"event":"iteration_started","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","title":"Iterate","inputs":{"items":["a","b"]}}}`,
+		`{"event":"node_started","workflow_run_id":"run-1","data":{"id":"end-1","node_id":"end","node_type":"end","predecessor_node_id":"iter"}}`,
+		`{"event":"iteration_completed","workflow_run_id":"run-1","data":{"id":"iter-1","node_id":"iter","node_type":"iteration","title":"Iterate","status":"failed","error":"boom","elapsed_time":1.5,"total_tokens":7,"steps":1,"outputs":{"output":["a"]},"finished_at":1705395333}}`,
+	}) {
+		trace.Add(event)
+	}
+
+	iteration := trace.Nodes[0]
+	want := "iter iteration Iterate failed boom 1.5 7 1 map[output:[a]] 1705395333"
+	got := fmt.Sprint(iteration.NodeID, " ", iteration.NodeType, " ", iteration.Title, " ", iteration.Status, " ", iteration.Error, " ",
+		iteration.ElapsedTime, " ", iteration.TotalTokens, " ", iteration.Steps, " ", iteration.Outputs, " ", iteration.FinishedAt)
+	if got != want {
+		t.Errorf("iteration node = %s, want %s", got, want)
+	}
+	if end := trace.Nodes[1]; end.Predecessor != iteration {
+		t.Errorf("end Predecessor = %v, want the iteration node", end.Predecessor)
+	}
+}
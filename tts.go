@@ -0,0 +1,100 @@
+package dify
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TTSAssembler - Splits a chat stream with text to speech auto-play into answer text and audio.
+// The Base64 MP3 fragments of the tts_message events are decoded and written in order to the audio writer,
+// while the other events are returned by Next and Current like a Stream.
+type TTSAssembler struct {
+	stream *Stream[Event]  // Underlying chat stream.
+	audio  io.Writer       // Writer receiving the decoded audio.
+	answer strings.Builder // Answer assembled from the message events.
+	err    error           // Error raised while decoding or writing the audio.
+}
+
+// NewTTSAssembler - Creates an assembler reading the chat stream and writing the decoded audio to audio.
+// Use an io.Pipe to consume the audio as an io.Reader while the stream is read.
+func NewTTSAssembler(stream *Stream[Event], audio io.Writer) *TTSAssembler {
+	return &TTSAssembler{stream: stream, audio: audio}
+}
+
+// Next - Advances to the next event other than tts_message and tts_message_end, writing their audio meanwhile.
+func (a *TTSAssembler) Next() bool {
+	if a.err != nil {
+		return false
+	}
+
+	for a.stream.Next() {
+		switch event := a.stream.Current().(type) {
+		case *TTSMessageEvent:
+			if err := a.writeAudio(event.Audio); err != nil {
+				a.err = err
+				a.stream.Close()
+				return false
+			}
+			continue
+		case *MessageEvent:
+			a.answer.WriteString(event.Answer)
+		case *MessageReplaceEvent:
+			a.answer.Reset()
+			a.answer.WriteString(event.Answer)
+		}
+		return true
+	}
+
+	return false
+}
+
+// Current - Returns the event read by the last successful call to Next.
+func (a *TTSAssembler) Current() Event {
+	return a.stream.Current()
+}
+
+// Answer - Returns the answer assembled from the message events read so far.
+func (a *TTSAssembler) Answer() string {
+	return a.answer.String()
+}
+
+// Err - Returns the error that stopped the assembler, from the audio or the underlying stream.
+func (a *TTSAssembler) Err() error {
+	if a.err != nil {
+		return a.err
+	}
+	return a.stream.Err()
+}
+
+// Close - Closes the underlying stream.
+func (a *TTSAssembler) Close() error {
+	return a.stream.Close()
+}
+
+// Run - Reads the stream to the end, writing all the audio, and returns the complete answer.
+func (a *TTSAssembler) Run() (string, error) {
+	defer a.Close()
+	for a.Next() {
+	}
+	return a.Answer(), a.Err()
+}
+
+// writeAudio - Decodes a Base64 audio fragment and writes it, empty fragments are skipped.
+func (a *TTSAssembler) writeAudio(fragment string) error {
+	if fragment == "" {
+		return nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(fragment)
+	if err != nil {
+		return fmt.Errorf("failed to decode audio: %w", err)
+	}
+
+	if _, err := a.audio.Write(data); err != nil {
+		return fmt.Errorf("failed to write audio: %w", err)
+	}
+
+	return nil
+}
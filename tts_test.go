@@ -0,0 +1,79 @@
+package dify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// newTTSStream - Creates a chat stream over the events, with a body recording whether it has been closed.
+func newTTSStream(events []string) (*Stream[Event], *closeRecorder) {
+	var input strings.Builder
+	for _, event := range events {
+		fmt.Fprintf(&input, "data: %s\n\n", event)
+	}
+	body := &closeRecorder{ReadCloser: io.NopCloser(strings.NewReader(input.String()))}
+	return newStream(context.Background(), body, 0, decodeEvent), body
+}
+
+func TestTTSAssemblerRun(t *testing.T) {
+	first, second := []byte("ID3\x04\x00audio"), []byte{0xff, 0xfb, 0x90, 0x00}
+	stream, body := newTTSStream([]string{
+		`{"event":"message","task_id":"task-1","message_id":"msg-1","answer":"Hello"}`,
+		`{"event":"tts_message","task_id":"task-1","message_id":"msg-1","audio":"` + base64.StdEncoding.EncodeToString(first) + `"}`,
+		`{"event":"message","task_id":"task-1","message_id":"msg-1","answer":", world"}`,
+		`{"event":"tts_message","task_id":"task-1","message_id":"msg-1","audio":"` + base64.StdEncoding.EncodeToString(second) + `"}`,
+		`{"event":"message_end","task_id":"task-1","message_id":"msg-1","conversation_id":"conv-1"}`,
+		`{"event":"tts_message_end","task_id":"task-1","message_id":"msg-1","audio":""}`,
+	})
+
+	var audio bytes.Buffer
+	assembler := NewTTSAssembler(stream, &audio)
+
+	var events []string
+	for assembler.Next() {
+		events = append(events, assembler.Current().EventName())
+	}
+	if err := assembler.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if got, want := strings.Join(events, ","), "message,message,message_end"; got != want {
+		t.Errorf("events = %s, want %s", got, want)
+	}
+	if got, want := assembler.Answer(), "Hello, world"; got != want {
+		t.Errorf("Answer() = %q, want %q", got, want)
+	}
+	if want := append(append([]byte{}, first...), second...); !bytes.Equal(audio.Bytes(), want) {
+		t.Errorf("audio = %x, want %x", audio.Bytes(), want)
+	}
+	if !body.closed.Load() {
+		t.Error("body not closed at the end of the stream")
+	}
+}
+
+func TestTTSAssemblerInvalidAudio(t *testing.T) {
+	stream, body := newTTSStream([]string{
+		`{"event":"message","task_id":"task-1","message_id":"msg-1","answer":"Hello"}`,
+		`{"event":"tts_message","task_id":"task-1","message_id":"msg-1","audio":"not base64!"}`,
+		`{"event":"message","task_id":"task-1","message_id":"msg-1","answer":", world"}`,
+	})
+
+	var audio bytes.Buffer
+	answer, err := NewTTSAssembler(stream, &audio).Run()
+	if err == nil || !strings.Contains(err.Error(), "failed to decode audio") {
+		t.Errorf("Run() error = %v, want a decode error", err)
+	}
+	if answer != "Hello" {
+		t.Errorf("Run() = %q, want the answer read before the error", answer)
+	}
+	if audio.Len() != 0 {
+		t.Errorf("audio = %x, want nothing", audio.Bytes())
+	}
+	if !body.closed.Load() {
+		t.Error("body not closed after the audio error")
+	}
+}
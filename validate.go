@@ -0,0 +1,214 @@
+package dify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError - Validation error of an input variable.
+type FieldError struct {
+	Variable string // Variable ID.
+	Message  string // Reason of the error.
+}
+
+// Error - Implements the error interface.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Variable, e.Message)
+}
+
+// InputValidationError - Errors of the input variables checked against the user input form of the application.
+type InputValidationError struct {
+	Fields []FieldError // Errors of the variables, in the order of the form.
+}
+
+// Error - Implements the error interface.
+func (e *InputValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return "invalid inputs: " + strings.Join(messages, "; ")
+}
+
+// ValidateInputs - Checks the inputs against the user input form, returning an *InputValidationError listing
+// the missing required variables, unknown select options, too long texts, non-number values and invalid files.
+func (p *AppParameters) ValidateInputs(inputs map[string]interface{}) error {
+	var fields []FieldError
+	for _, item := range p.UserInputForm {
+		control := item.Control()
+		if control == nil {
+			continue
+		}
+
+		value, ok := inputs[control.Variable]
+		if !ok || isEmptyInput(value) {
+			if control.Required {
+				fields = append(fields, FieldError{Variable: control.Variable, Message: "is required"})
+			}
+			continue
+		}
+
+		if message := validateInput(item, value); message != "" {
+			fields = append(fields, FieldError{Variable: control.Variable, Message: message})
+		}
+	}
+
+	if len(fields) > 0 {
+		return &InputValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// ValidateInputs - Checks the inputs against the user input form of the application, fetched once and cached.
+func (c *Client) ValidateInputs(ctx context.Context, inputs map[string]interface{}) error {
+	parameters, err := c.appParameters(ctx)
+	if err != nil {
+		return err
+	}
+	return parameters.ValidateInputs(inputs)
+}
+
+// validateInputs - Validates the inputs of a request when ValidateInputs is enabled in the configuration.
+func (c *Client) validateInputs(ctx context.Context, inputs map[string]interface{}) error {
+	if !c.config.ValidateInputs {
+		return nil
+	}
+	return c.ValidateInputs(ctx, inputs)
+}
+
+// appParameters - Returns the parameters of the application, fetching them until a call succeeds. The lock is not
+// held during the request, so concurrent first calls may each fetch the parameters and the first one stored wins.
+func (c *Client) appParameters(ctx context.Context) (*AppParameters, error) {
+	c.mu.Lock()
+	parameters := c.parameters
+	c.mu.Unlock()
+	if parameters != nil {
+		return parameters, nil
+	}
+
+	parameters, err := c.GetAppParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the user input form: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.parameters == nil {
+		c.parameters = parameters
+	}
+
+	return c.parameters, nil
+}
+
+// validateInput - Validates a non-empty value against its control, returning the reason of the error if any.
+func validateInput(item UserInputFormItem, value interface{}) string {
+	switch {
+	case item.TextInput != nil, item.Paragraph != nil:
+		control := item.TextInput
+		if control == nil {
+			control = item.Paragraph
+		}
+		text, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if control.MaxLength > 0 && utf8.RuneCountInString(text) > control.MaxLength {
+			return fmt.Sprintf("must be at most %d characters", control.MaxLength)
+		}
+	case item.Select != nil:
+		option, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if len(item.Select.Options) > 0 && !contains(item.Select.Options, option) {
+			return fmt.Sprintf("must be one of %s", strings.Join(item.Select.Options, ", "))
+		}
+	case item.Number != nil:
+		switch number := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		case string:
+			if _, err := strconv.ParseFloat(number, 64); err != nil {
+				return "must be a number"
+			}
+		default:
+			return "must be a number"
+		}
+	case item.File != nil:
+		file, ok := value.(File)
+		if pointer, isPointer := value.(*File); isPointer {
+			file, ok = *pointer, true
+		}
+		if !ok {
+			return "must be a File"
+		}
+		return validateFileInput(item.File, file)
+	case item.FileList != nil:
+		var files []File
+		switch list := value.(type) {
+		case []File:
+			files = list
+		case []*File:
+			for _, file := range list {
+				if file != nil {
+					files = append(files, *file)
+				}
+			}
+		default:
+			return "must be a list of File"
+		}
+		if item.FileList.MaxLength > 0 && len(files) > item.FileList.MaxLength {
+			return fmt.Sprintf("must have at most %d files", item.FileList.MaxLength)
+		}
+		for i, file := range files {
+			if message := validateFileInput(item.FileList, file); message != "" {
+				return fmt.Sprintf("file %d %s", i, message)
+			}
+		}
+	}
+	return ""
+}
+
+// validateFileInput - Validates a file against the allowed types and transfer methods of its control.
+func validateFileInput(control *FileControl, file File) string {
+	if err := file.Validate(); err != nil {
+		return err.Error()
+	}
+	if len(control.AllowedFileTypes) > 0 && !contains(control.AllowedFileTypes, file.Type) {
+		return fmt.Sprintf("has type %s, which is not allowed", file.Type)
+	}
+	if len(control.AllowedFileUploadMethods) > 0 && !contains(control.AllowedFileUploadMethods, file.TransferMethod) {
+		return fmt.Sprintf("has transfer method %s, which is not allowed", file.TransferMethod)
+	}
+	return ""
+}
+
+// isEmptyInput - Reports whether an input value counts as missing for a required variable.
+func isEmptyInput(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case *File:
+		return v == nil
+	case []File:
+		return len(v) == 0
+	case []*File:
+		return len(v) == 0
+	}
+	return false
+}
+
+// contains - Reports whether the value is in the list.
+func contains[T comparable](list []T, value T) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
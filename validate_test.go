@@ -0,0 +1,169 @@
+package dify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// validateForm - User input form of the validation tests.
+const validateForm = `{"user_input_form":[
+	{"text-input":{"label":"Name","variable":"name","required":true,"max_length":5}},
+	{"paragraph":{"label":"Bio","variable":"bio","required":false}},
+	{"select":{"label":"Color","variable":"color","required":false,"options":["red","blue"]}},
+	{"number":{"label":"Age","variable":"age","required":false}},
+	{"file-list":{"label":"Images","variable":"images","required":false,"allowed_file_types":["image"],"allowed_file_upload_methods":["local_file"],"max_length":2}}
+]}`
+
+func TestAppParametersValidateInputs(t *testing.T) {
+	var parameters AppParameters
+	if err := json.Unmarshal([]byte(validateForm), &parameters); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	image := File{Type: FileTypeImage, TransferMethod: TransferMethodLocalFile, UploadFileID: "file-1"}
+	tests := []struct {
+		name   string
+		inputs map[string]interface{}
+		want   []FieldError // Nil if the inputs are valid.
+	}{
+		{
+			name:   "valid",
+			inputs: map[string]interface{}{"name": "Ann", "color": "red", "age": 42, "images": []File{image}},
+		},
+		{
+			name:   "required missing",
+			inputs: map[string]interface{}{},
+			want:   []FieldError{{Variable: "name", Message: "is required"}},
+		},
+		{
+			name:   "required empty",
+			inputs: map[string]interface{}{"name": ""},
+			want:   []FieldError{{Variable: "name", Message: "is required"}},
+		},
+		{
+			name:   "max_length in characters",
+			inputs: map[string]interface{}{"name": "Zoë é"},
+		},
+		{
+			name:   "max_length exceeded",
+			inputs: map[string]interface{}{"name": "Annabel"},
+			want:   []FieldError{{Variable: "name", Message: "must be at most 5 characters"}},
+		},
+		{
+			name:   "text not a string",
+			inputs: map[string]interface{}{"name": "Ann", "bio": 1},
+			want:   []FieldError{{Variable: "bio", Message: "must be a string"}},
+		},
+		{
+			name:   "select unknown option",
+			inputs: map[string]interface{}{"name": "Ann", "color": "green"},
+			want:   []FieldError{{Variable: "color", Message: "must be one of red, blue"}},
+		},
+		{
+			name:   "number types",
+			inputs: map[string]interface{}{"name": "Ann", "age": json.Number("4.2")},
+		},
+		{
+			name:   "number string",
+			inputs: map[string]interface{}{"name": "Ann", "age": "-1.5e3"},
+		},
+		{
+			name:   "number invalid string",
+			inputs: map[string]interface{}{"name": "Ann", "age": "forty"},
+			want:   []FieldError{{Variable: "age", Message: "must be a number"}},
+		},
+		{
+			name:   "number invalid type",
+			inputs: map[string]interface{}{"name": "Ann", "age": true},
+			want:   []FieldError{{Variable: "age", Message: "must be a number"}},
+		},
+		{
+			name:   "file-list pointers",
+			inputs: map[string]interface{}{"name": "Ann", "images": []*File{&image, &image}},
+		},
+		{
+			name:   "file-list too long",
+			inputs: map[string]interface{}{"name": "Ann", "images": []File{image, image, image}},
+			want:   []FieldError{{Variable: "images", Message: "must have at most 2 files"}},
+		},
+		{
+			name:   "file-list type not allowed",
+			inputs: map[string]interface{}{"name": "Ann", "images": []File{image, {Type: FileTypeDocument, TransferMethod: TransferMethodLocalFile, UploadFileID: "file-2"}}},
+			want:   []FieldError{{Variable: "images", Message: "file 1 has type document, which is not allowed"}},
+		},
+		{
+			name:   "file-list transfer method not allowed",
+			inputs: map[string]interface{}{"name": "Ann", "images": []File{{Type: FileTypeImage, TransferMethod: TransferMethodRemoteURL, Url: "https://example.com/a.png"}}},
+			want:   []FieldError{{Variable: "images", Message: "file 0 has transfer method remote_url, which is not allowed"}},
+		},
+		{
+			name:   "file-list not a list",
+			inputs: map[string]interface{}{"name": "Ann", "images": image},
+			want:   []FieldError{{Variable: "images", Message: "must be a list of File"}},
+		},
+		{
+			name:   "several errors in form order",
+			inputs: map[string]interface{}{"color": "green", "age": "x"},
+			want: []FieldError{
+				{Variable: "name", Message: "is required"},
+				{Variable: "color", Message: "must be one of red, blue"},
+				{Variable: "age", Message: "must be a number"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parameters.ValidateInputs(tt.inputs)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("ValidateInputs() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *InputValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("ValidateInputs() error = %v, want *InputValidationError", err)
+			}
+			if !reflect.DeepEqual(validationErr.Fields, tt.want) {
+				t.Errorf("Fields = %v, want %v", validationErr.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientValidateInputsRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(validateForm))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "app-key"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if err := client.ValidateInputs(context.Background(), map[string]interface{}{"name": "Ann"}); err == nil {
+		t.Fatal("first ValidateInputs() error = nil, want the fetch error")
+	}
+	for i := 0; i < 2; i++ {
+		if err := client.ValidateInputs(context.Background(), map[string]interface{}{"name": "Ann"}); err != nil {
+			t.Fatalf("ValidateInputs() error = %v", err)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("parameters fetched %d times, want 2", n)
+	}
+}
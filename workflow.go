@@ -1,12 +1,8 @@
 package dify
 
 import (
-	"bufio"
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 // WorkflowEndpoint - Endpoint for workflows.
@@ -14,112 +10,78 @@ const WorkflowEndpoint = "/v1/workflows"
 
 // RunWorkflowRequest - Request body for running a workflow.
 type RunWorkflowRequest struct {
-	Inputs       map[string]interface{} `json:"inputs"`        // User input/question content.
+	Inputs       map[string]interface{} `json:"inputs"`        // User input/question content, file variables take a File or a []File.
 	ResponseMode ResponseMode           `json:"response_mode"` // Response mode, `streaming`(recommended) or `blocking`.
 	User         string                 `json:"user"`          // Identity of the end user.
 	Files        []File                 `json:"files"`         // Uploaded files.
+	WorkflowID   string                 `json:"-"`             // Published workflow version to run, the latest published one if empty.
 }
 
-// RunWorkflow - Runs a workflow in blocking mode.
-func (c *Client) RunWorkflow(ctx context.Context, req RunWorkflowRequest) (*CompletionResponse, error) {
-	url := fmt.Sprintf("%s%s/run", c.config.BaseURL, WorkflowEndpoint)
-
-	req.ResponseMode = BlockingMode
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %v", err)
+// runPath - Returns the endpoint path running the workflow version of the request.
+func (r RunWorkflowRequest) runPath() string {
+	if r.WorkflowID == "" {
+		return WorkflowEndpoint + "/run"
 	}
+	return fmt.Sprintf("%s/%s/run", WorkflowEndpoint, r.WorkflowID)
+}
 
-	request, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+// RunWorkflow - Runs a workflow in blocking mode, the version set by req.WorkflowID or the latest published one.
+func (c *Client) RunWorkflow(ctx context.Context, req RunWorkflowRequest) (*CompletionResponse, error) {
+	if err := validateFiles(req.Files, req.Inputs); err != nil {
+		return nil, err
 	}
-
-	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	request.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
+	if err := c.validateInputs(ctx, req.Inputs); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		buf := &bytes.Buffer{}
-		_, err = buf.ReadFrom(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %v", err)
-		}
-		return nil, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, buf.String())
+	req.ResponseMode = BlockingMode
+	request, err := c.newRequest(ctx, "POST", req.runPath(), req)
+	if err != nil {
+		return nil, err
 	}
 
 	var response CompletionResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode response: %v", err)
+	if err := c.do(request, &response); err != nil {
+		return nil, err
 	}
 
 	return &response, nil
 }
 
-// RunWorkflowStream - Runs a workflow in streaming mode.
-func (c *Client) RunWorkflowStream(ctx context.Context, req RunWorkflowRequest) (<-chan ChunkCompletionResponse, error) {
-	url := fmt.Sprintf("%s%s/run", c.config.BaseURL, WorkflowEndpoint)
-
-	req.ResponseMode = StreamingMode
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %v", err)
+// RunWorkflowStream - Runs a workflow in streaming mode, the version set by req.WorkflowID or the latest published one.
+func (c *Client) RunWorkflowStream(ctx context.Context, req RunWorkflowRequest) (*Stream[Event], error) {
+	if err := validateFiles(req.Files, req.Inputs); err != nil {
+		return nil, err
+	}
+	if err := c.validateInputs(ctx, req.Inputs); err != nil {
+		return nil, err
 	}
 
-	request, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	req.ResponseMode = StreamingMode
+	request, err := c.newRequest(ctx, "POST", req.runPath(), req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	request.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(request)
+	resp, err := c.send(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		buf := &bytes.Buffer{}
-		_, err = buf.ReadFrom(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %v", err)
-		}
-		return nil, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, buf.String())
+	stream := newStream(ctx, resp.Body, c.config.MaxEventSize, decodeEvent)
+	stream.stop = func(ctx context.Context, taskID string) error {
+		return c.StopWorkflow(ctx, taskID, req.User)
 	}
 
-	stream := make(chan ChunkCompletionResponse)
-	go func() {
-		defer close(stream)
-
-		scanner := bufio.NewScanner(resp.Body)
-		for scanner.Scan() {
-			line := scanner.Bytes()
-
-			if bytes.HasPrefix(line, []byte("data: ")) {
-				data := bytes.TrimPrefix(line, []byte("data: "))
-
-				var chunk ChunkCompletionResponse
-				if err := json.Unmarshal(data, &chunk); err != nil {
-					fmt.Printf("failed to unmarshal chunk: %v\n", err)
-					return
-				}
-
-				stream <- chunk
-			}
-		}
+	return stream, nil
+}
 
-		if err := scanner.Err(); err != nil {
-			fmt.Printf("error reading response body: %v\n", err)
-		}
-	}()
+// StopWorkflow - Stops a workflow task started in streaming mode.
+func (c *Client) StopWorkflow(ctx context.Context, taskID, user string) error {
+	request, err := c.newRequest(ctx, "POST", fmt.Sprintf("%s/tasks/%s/stop", WorkflowEndpoint, taskID), stopRequest{User: user})
+	if err != nil {
+		return err
+	}
 
-	return stream, nil
+	return c.do(request, nil)
 }
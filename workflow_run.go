@@ -0,0 +1,177 @@
+package dify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// WorkflowRun - Details of a workflow execution.
+type WorkflowRun struct {
+	ID          string                 `json:"id"`                // Workflow execution ID.
+	WorkflowID  string                 `json:"workflow_id"`       // Associated Workflow ID, the version that ran.
+	Version     string                 `json:"version,omitempty"` // Workflow version, in the workflow logs.
+	Status      string                 `json:"status"`            // Execution status, running/succeeded/failed/stopped.
+	Inputs      map[string]interface{} `json:"inputs"`            // Input content.
+	Outputs     map[string]interface{} `json:"outputs"`           // Output content.
+	Error       string                 `json:"error"`             // The reason for the error.
+	TotalSteps  int                    `json:"total_steps"`       // Total number of steps.
+	TotalTokens int                    `json:"total_tokens"`      // Total tokens used.
+	ElapsedTime float64                `json:"elapsed_time"`      // Time consumed (s).
+	CreatedAt   int                    `json:"created_at"`        // Start time.
+	FinishedAt  int                    `json:"finished_at"`       // End time, 0 while running.
+}
+
+// UnmarshalJSON - Decodes the inputs and outputs sent either as objects or as JSON encoded strings.
+func (r *WorkflowRun) UnmarshalJSON(data []byte) error {
+	type run WorkflowRun
+	var value struct {
+		run
+		Inputs  json.RawMessage `json:"inputs"`
+		Outputs json.RawMessage `json:"outputs"`
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*r = WorkflowRun(value.run)
+	var err error
+	if r.Inputs, err = decodeJSONObject(value.Inputs); err != nil {
+		return fmt.Errorf("failed to unmarshal inputs: %w", err)
+	}
+	if r.Outputs, err = decodeJSONObject(value.Outputs); err != nil {
+		return fmt.Errorf("failed to unmarshal outputs: %w", err)
+	}
+	return nil
+}
+
+// decodeJSONObject - Decodes a JSON object, possibly encoded in a JSON string, nil for null or empty data.
+func decodeJSONObject(data json.RawMessage) (map[string]interface{}, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err == nil {
+		if encoded == "" {
+			return nil, nil
+		}
+		data = json.RawMessage(encoded)
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// WorkflowLogAccount - Account who ran a workflow from the console.
+type WorkflowLogAccount struct {
+	ID    string `json:"id"`    // Account ID.
+	Name  string `json:"name"`  // Account name.
+	Email string `json:"email"` // Account email.
+}
+
+// WorkflowLogEndUser - End user who ran a workflow.
+type WorkflowLogEndUser struct {
+	ID          string `json:"id"`           // End user ID.
+	Type        string `json:"type"`         // End user type, such as `service_api`.
+	IsAnonymous bool   `json:"is_anonymous"` // Whether the end user is anonymous.
+	SessionID   string `json:"session_id"`   // Session ID, the `user` identity of the requests.
+}
+
+// WorkflowLog - Log entry of a workflow execution.
+type WorkflowLog struct {
+	ID               string              `json:"id"`                  // Log ID.
+	WorkflowRun      WorkflowRun         `json:"workflow_run"`        // Workflow execution.
+	CreatedFrom      string              `json:"created_from"`        // Source of the execution, such as `service-api`.
+	CreatedByRole    string              `json:"created_by_role"`     // Role of the creator, `account` or `end_user`.
+	CreatedByAccount *WorkflowLogAccount `json:"created_by_account"`  // Account creator, nil for end users.
+	CreatedByEndUser *WorkflowLogEndUser `json:"created_by_end_user"` // End user creator, nil for accounts.
+	CreatedAt        int                 `json:"created_at"`          // Creation timestamp, such as: 1705395332.
+}
+
+// WorkflowLogsRequest - Request parameters for listing the workflow logs.
+type WorkflowLogsRequest struct {
+	Keyword                   string // Keyword to search.
+	Status                    string // Execution status, `succeeded`, `failed` or `stopped`.
+	Page                      int    // Page number, 1 by default.
+	Limit                     int    // Number of records per page, 20 by default.
+	CreatedByEndUserSessionID string // Only the executions of this end user `user` identity.
+	CreatedByAccount          string // Only the executions of this account email.
+}
+
+// WorkflowLogsResponse - Response body from the ListWorkflowLogs endpoint.
+type WorkflowLogsResponse struct {
+	Page    int           `json:"page"`     // Current page.
+	Limit   int           `json:"limit"`    // Number of records per page.
+	Total   int           `json:"total"`    // Total number of records.
+	HasMore bool          `json:"has_more"` // Whether there is a next page.
+	Data    []WorkflowLog `json:"data"`     // List of logs.
+}
+
+// GetWorkflowRun - Gets the details of a workflow execution by its workflow_run_id.
+func (c *Client) GetWorkflowRun(ctx context.Context, runID string) (*WorkflowRun, error) {
+	request, err := c.newRequest(ctx, "GET", fmt.Sprintf("%s/run/%s", WorkflowEndpoint, runID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response WorkflowRun
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListWorkflowLogs - Lists the workflow logs, the most recent first.
+func (c *Client) ListWorkflowLogs(ctx context.Context, req WorkflowLogsRequest) (*WorkflowLogsResponse, error) {
+	query := url.Values{}
+	if req.Keyword != "" {
+		query.Set("keyword", req.Keyword)
+	}
+	if req.Status != "" {
+		query.Set("status", req.Status)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+	if req.CreatedByEndUserSessionID != "" {
+		query.Set("created_by_end_user_session_id", req.CreatedByEndUserSessionID)
+	}
+	if req.CreatedByAccount != "" {
+		query.Set("created_by_account", req.CreatedByAccount)
+	}
+
+	request, err := c.newRequest(ctx, "GET", WorkflowEndpoint+"/logs?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response WorkflowLogsResponse
+	if err := c.do(request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// WorkflowLogs - Returns a pager over the workflow logs from req.Page, fetching req.Limit logs per page.
+func (c *Client) WorkflowLogs(ctx context.Context, req WorkflowLogsRequest) *Pager[WorkflowLog] {
+	return newPagePager(ctx, req.Page, func(ctx context.Context, page int) ([]WorkflowLog, bool, error) {
+		req.Page = page
+		response, err := c.ListWorkflowLogs(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response.Data, response.HasMore, nil
+	})
+}
@@ -0,0 +1,161 @@
+package dify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultPollInterval - Default interval between two polls of a workflow execution.
+const DefaultPollInterval = 2 * time.Second
+
+// WorkflowTask - Workflow started in streaming mode, followed in the background until it finishes.
+type WorkflowTask struct {
+	TaskID        string        // Task ID, used to stop the workflow.
+	WorkflowRunID string        // Workflow execution ID, used to poll the execution.
+	PollInterval  time.Duration // Interval between two polls when the stream is lost, DefaultPollInterval if 0.
+
+	client *Client             // Client that started the workflow.
+	user   string              // Identity of the end user.
+	done   chan struct{}       // Closed once the stream has ended.
+	result *CompletionResponse // Result from the workflow_finished event, nil if the stream ended without it.
+	err    error               // Error that ended the stream early.
+}
+
+// StartWorkflow - Runs a workflow in streaming mode and returns as soon as the execution has started, so that
+// long workflows are not cut by gateway timeouts of the blocking mode. The stream is followed in the background
+// until ctx is done, Wait then polls the execution if the stream was lost before the workflow finished.
+func (c *Client) StartWorkflow(ctx context.Context, req RunWorkflowRequest) (*WorkflowTask, error) {
+	stream, err := c.RunWorkflowStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &WorkflowTask{client: c, user: req.User, done: make(chan struct{})}
+	for task.WorkflowRunID == "" && stream.Next() {
+		switch event := stream.Current().(type) {
+		case *WorkflowStartedEvent:
+			task.TaskID, task.WorkflowRunID = event.TaskID, event.WorkflowRunID
+		case *ErrorEvent:
+			stream.Close()
+			return nil, event.APIError()
+		}
+	}
+	if task.WorkflowRunID == "" {
+		stream.Close()
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("stream ended before the workflow started")
+	}
+
+	go task.follow(stream)
+
+	return task, nil
+}
+
+// Wait - Waits until the workflow finishes and returns its result, as RunWorkflow would. The result is taken
+// from the stream, or from polling GetWorkflowRun until succeeded, failed or stopped if the stream was lost.
+func (t *WorkflowTask) Wait(ctx context.Context) (*CompletionResponse, error) {
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if t.result != nil {
+		return t.result, nil
+	}
+
+	return t.poll(ctx)
+}
+
+// Err - Returns the error that ended the stream before the workflow finished, nil until the stream has ended.
+func (t *WorkflowTask) Err() error {
+	select {
+	case <-t.done:
+		return t.err
+	default:
+		return nil
+	}
+}
+
+// Stop - Stops the workflow.
+func (t *WorkflowTask) Stop(ctx context.Context) error {
+	return t.client.StopWorkflow(ctx, t.TaskID, t.user)
+}
+
+// follow - Reads the stream until the workflow_finished event or the end of the stream.
+func (t *WorkflowTask) follow(stream *Stream[Event]) {
+	defer close(t.done)
+	defer stream.Close()
+
+	for stream.Next() {
+		switch event := stream.Current().(type) {
+		case *WorkflowFinishedEvent:
+			t.result = &CompletionResponse{WorkflowRunID: event.WorkflowRunID, TaskID: event.TaskID, Data: event.Data}
+			return
+		case *ErrorEvent:
+			t.err = event.APIError()
+			return
+		}
+	}
+
+	t.err = stream.Err()
+	if t.err == nil {
+		t.err = fmt.Errorf("stream ended before the workflow finished")
+	}
+}
+
+// poll - Polls the workflow execution until it is no longer running. Transport errors and 5xx responses are
+// retried on the next tick, only the end of ctx or another API error stops the polling.
+func (t *WorkflowTask) poll(ctx context.Context) (*CompletionResponse, error) {
+	interval := t.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := t.client.GetWorkflowRun(ctx, t.WorkflowRunID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+				return nil, err
+			}
+		} else {
+			switch run.Status {
+			case "succeeded", "partial-succeeded", "failed", "stopped":
+				return &CompletionResponse{
+					WorkflowRunID: t.WorkflowRunID,
+					TaskID:        t.TaskID,
+					Data: WorkflowResult{
+						ID:          run.ID,
+						WorkflowID:  run.WorkflowID,
+						Status:      run.Status,
+						Outputs:     run.Outputs,
+						Error:       run.Error,
+						ElapsedTime: run.ElapsedTime,
+						TotalTokens: run.TotalTokens,
+						TotalSteps:  run.TotalSteps,
+						CreatedAt:   run.CreatedAt,
+						FinishedAt:  run.FinishedAt,
+					},
+				}, nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
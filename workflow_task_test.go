@@ -0,0 +1,166 @@
+package dify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newWorkflowTaskServer - Starts a server streaming the events on /v1/workflows/run, then answering the polls of
+// /v1/workflows/run/run-1 with the handlers in order, the last one being repeated.
+func newWorkflowTaskServer(t *testing.T, events []string, polls ...http.HandlerFunc) (*Client, *atomic.Int32) {
+	t.Helper()
+
+	var count atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/workflows/run", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			fmt.Fprintf(w, "data: %s\n\n", event)
+		}
+	})
+	mux.HandleFunc("/v1/workflows/run/run-1", func(w http.ResponseWriter, r *http.Request) {
+		i := int(count.Add(1)) - 1
+		if i >= len(polls) {
+			i = len(polls) - 1
+		}
+		polls[i](w, r)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "app-key"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	return client, &count
+}
+
+// workflowRunHandler - Answers a poll with a workflow execution in the status.
+func workflowRunHandler(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"run-1","workflow_id":"wf-1","status":%q,"outputs":{"answer":"42"},"total_steps":2}`, status)
+	}
+}
+
+// dropConnection - Answers a poll by closing the connection, failing the request in the transport.
+func dropConnection(w http.ResponseWriter, r *http.Request) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err == nil {
+		conn.Close()
+	}
+}
+
+func TestStartWorkflowStream(t *testing.T) {
+	client, polls := newWorkflowTaskServer(t, workflowEvents, workflowRunHandler("failed"))
+
+	task, err := client.StartWorkflow(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("StartWorkflow() error = %v", err)
+	}
+	if task.TaskID != "task-1" || task.WorkflowRunID != "run-1" {
+		t.Errorf("task = %s/%s, want task-1/run-1", task.TaskID, task.WorkflowRunID)
+	}
+
+	result, err := task.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if result.Data.Status != "succeeded" || result.Data.Outputs["answer"] != "42" {
+		t.Errorf("Wait() = %+v, want the workflow_finished data", result.Data)
+	}
+	if err := task.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+	if n := polls.Load(); n != 0 {
+		t.Errorf("polls = %d, want 0", n)
+	}
+}
+
+func TestStartWorkflowPoll(t *testing.T) {
+	client, polls := newWorkflowTaskServer(t, workflowEvents[:1],
+		dropConnection,
+		func(w http.ResponseWriter, r *http.Request) { http.Error(w, "bad gateway", http.StatusBadGateway) },
+		workflowRunHandler("running"),
+		workflowRunHandler("succeeded"),
+	)
+
+	task, err := client.StartWorkflow(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("StartWorkflow() error = %v", err)
+	}
+	task.PollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := task.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if result.WorkflowRunID != "run-1" || result.TaskID != "task-1" || result.Data.Status != "succeeded" || result.Data.TotalSteps != 2 {
+		t.Errorf("Wait() = %+v, want the polled execution", result)
+	}
+	if task.Err() == nil {
+		t.Error("Err() = nil, want the end of the stream")
+	}
+	if n := polls.Load(); n != 4 {
+		t.Errorf("polls = %d, want 4", n)
+	}
+}
+
+func TestStartWorkflowPollError(t *testing.T) {
+	client, _ := newWorkflowTaskServer(t, workflowEvents[:1], func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"status":404,"code":"not_found","message":"Workflow run not found"}`)
+	})
+
+	task, err := client.StartWorkflow(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("StartWorkflow() error = %v", err)
+	}
+	task.PollInterval = 10 * time.Millisecond
+
+	_, err = task.Wait(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Wait() error = %v, want a 404 *APIError", err)
+	}
+}
+
+func TestStartWorkflowPollContext(t *testing.T) {
+	client, _ := newWorkflowTaskServer(t, workflowEvents[:1], workflowRunHandler("running"))
+
+	task, err := client.StartWorkflow(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("StartWorkflow() error = %v", err)
+	}
+	task.PollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := task.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestStartWorkflowErrorEvent(t *testing.T) {
+	client, _ := newWorkflowTaskServer(t, []string{
+		`{"event":"error","task_id":"task-1","status":400,"code":"invalid_param","message":"inputs is required"}`,
+	}, workflowRunHandler("succeeded"))
+
+	task, err := client.StartWorkflow(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if task != nil {
+		t.Errorf("StartWorkflow() task = %+v, want nil", task)
+	}
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Errorf("StartWorkflow() error = %v, want %v", err, ErrInvalidParam)
+	}
+}
@@ -0,0 +1,218 @@
+package dify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// workflowEvents - Events of a workflow run with a single node, in the order Dify streams them.
+var workflowEvents = []string{
+	`{"event":"workflow_started","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"run-1","workflow_id":"wf-1","sequence_number":1,"created_at":1705395332}}`,
+	`{"event":"node_started","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"exec-1","node_id":"start","node_type":"start","title":"Start","index":1,"created_at":1705395332}}`,
+	`{"event":"node_finished","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"exec-1","node_id":"start","node_type":"start","title":"Start","index":1,"status":"succeeded","created_at":1705395332}}`,
+	`{"event":"workflow_finished","task_id":"task-1","workflow_run_id":"run-1","data":{"id":"run-1","workflow_id":"wf-1","status":"succeeded","outputs":{"answer":"42"},"created_at":1705395332}}`,
+}
+
+// closeRecorder - Response body recording whether it has been closed.
+type closeRecorder struct {
+	io.ReadCloser
+	closed atomic.Bool
+}
+
+// Close - Records the close and closes the body.
+func (r *closeRecorder) Close() error {
+	r.closed.Store(true)
+	return r.ReadCloser.Close()
+}
+
+// recordingTransport - Transport wrapping the response bodies in a closeRecorder.
+type recordingTransport struct {
+	body atomic.Pointer[closeRecorder]
+}
+
+// RoundTrip - Sends the request and records the response body.
+func (t *recordingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	body := &closeRecorder{ReadCloser: resp.Body}
+	t.body.Store(body)
+	resp.Body = body
+	return resp, nil
+}
+
+// newWorkflowServer - Starts a server streaming the events, then holding the response open if hold is set.
+func newWorkflowServer(t *testing.T, events []string, hold bool) (*Client, *recordingTransport) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/workflows/run" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			fmt.Fprintf(w, "data: %s\n\n", event)
+		}
+		w.(http.Flusher).Flush()
+		if hold {
+			<-r.Context().Done()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "app-key"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	transport := &recordingTransport{}
+	client.client.Transport = transport
+
+	return client, transport
+}
+
+// waitClosed - Fails the test if the response body is not closed shortly.
+func waitClosed(t *testing.T, transport *recordingTransport) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !transport.body.Load().closed.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("response body not closed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunWorkflowStreamEvents(t *testing.T) {
+	client, transport := newWorkflowServer(t, workflowEvents, false)
+
+	stream, err := client.RunWorkflowStream(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("RunWorkflowStream() error = %v", err)
+	}
+
+	var names []string
+	for stream.Next() {
+		names = append(names, stream.Current().EventName())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	want := []string{"workflow_started", "node_started", "node_finished", "workflow_finished"}
+	if fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Fatalf("events = %v, want %v", names, want)
+	}
+	if stream.TaskID() != "task-1" {
+		t.Errorf("TaskID() = %q, want %q", stream.TaskID(), "task-1")
+	}
+	if !transport.body.Load().closed.Load() {
+		t.Error("response body not closed at the end of the stream")
+	}
+}
+
+func TestRunWorkflowStreamClose(t *testing.T) {
+	client, transport := newWorkflowServer(t, workflowEvents[:1], true)
+
+	stream, err := client.RunWorkflowStream(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("RunWorkflowStream() error = %v", err)
+	}
+	if !stream.Next() {
+		t.Fatalf("Next() = false, Err() = %v", stream.Err())
+	}
+
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !transport.body.Load().closed.Load() {
+		t.Fatal("response body not closed by Close")
+	}
+	if stream.Next() {
+		t.Fatal("Next() = true after Close")
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestRunWorkflowStreamCloseWhileReading(t *testing.T) {
+	client, transport := newWorkflowServer(t, workflowEvents[:1], true)
+
+	stream, err := client.RunWorkflowStream(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("RunWorkflowStream() error = %v", err)
+	}
+	if !stream.Next() {
+		t.Fatalf("Next() = false, Err() = %v", stream.Err())
+	}
+
+	time.AfterFunc(10*time.Millisecond, func() { stream.Close() })
+	if stream.Next() {
+		t.Fatal("Next() = true after Close")
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+	waitClosed(t, transport)
+}
+
+func TestRunWorkflowStreamContextCancel(t *testing.T) {
+	client, transport := newWorkflowServer(t, workflowEvents[:1], true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.RunWorkflowStream(ctx, RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("RunWorkflowStream() error = %v", err)
+	}
+	if !stream.Next() {
+		t.Fatalf("Next() = false, Err() = %v", stream.Err())
+	}
+
+	cancel()
+	waitClosed(t, transport)
+	if stream.Next() {
+		t.Fatal("Next() = true after cancel")
+	}
+	if err := stream.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRunWorkflowStreamErrorEvent(t *testing.T) {
+	events := []string{
+		workflowEvents[0],
+		`{"event":"error","task_id":"task-1","message_id":"","status":400,"code":"provider_quota_exceeded","message":"Your quota has been exhausted."}`,
+	}
+	client, transport := newWorkflowServer(t, events, false)
+
+	stream, err := client.RunWorkflowStream(context.Background(), RunWorkflowRequest{User: "user-1"})
+	if err != nil {
+		t.Fatalf("RunWorkflowStream() error = %v", err)
+	}
+
+	var last Event
+	for stream.Next() {
+		last = stream.Current()
+	}
+	if _, ok := last.(*ErrorEvent); !ok {
+		t.Errorf("last event = %T, want *ErrorEvent", last)
+	}
+	if err := stream.Err(); !errors.Is(err, ErrProviderQuotaExceeded) {
+		t.Errorf("Err() = %v, want %v", err, ErrProviderQuotaExceeded)
+	}
+	if !transport.body.Load().closed.Load() {
+		t.Error("response body not closed after the error event")
+	}
+}